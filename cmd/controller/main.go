@@ -1,35 +1,82 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/roshbhatia/kubanana/pkg/audit"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	"github.com/roshbhatia/kubanana/pkg/config"
 	"github.com/roshbhatia/kubanana/pkg/controller"
+	"github.com/roshbhatia/kubanana/pkg/health"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	"github.com/roshbhatia/kubanana/pkg/tracing"
 	"github.com/roshbhatia/kubanana/pkg/util"
+	"github.com/roshbhatia/kubanana/pkg/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/klog/v2"
 )
 
 func main() {
 	klog.InitFlags(nil)
-	var kubeconfig string
+	var configFile string
 	var masterURL string
 
-	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	configuration := config.NewDefault()
+	config.AddFlags(flag.CommandLine, configuration)
+	flag.StringVar(&configFile, "config", "", "Path to a KubananaConfiguration file. Flags given on the command line take precedence over it.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	flag.Parse()
 
-	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if configFile != "" {
+		loaded, err := config.Load(configFile)
+		if err != nil {
+			klog.Fatalf("Error loading configuration: %s", err.Error())
+		}
+		if err := config.OverrideFromFlags(loaded, flag.CommandLine); err != nil {
+			klog.Fatalf("Error applying flags to configuration: %s", err.Error())
+		}
+		configuration = loaded
+	}
+	if errs := config.Validate(configuration); len(errs) > 0 {
+		klog.Fatalf("Invalid configuration: %s", errs.ToAggregate().Error())
+	}
+	if configuration.Logging.Format == config.JSONLogFormat {
+		klog.SetLogger(newJSONLogger())
+	}
+
+	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, configuration.ClientConnection.Kubeconfig)
 	if err != nil {
 		klog.Fatalf("Error building kubeconfig: %s", err.Error())
 	}
+	cfg.QPS = configuration.ClientConnection.QPS
+	cfg.Burst = int(configuration.ClientConnection.Burst)
 
 	kubeClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		klog.Fatalf("Error building kubernetes clientset: %s", err.Error())
 	}
 
+	kubananaClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Error building kubanana clientset: %s", err.Error())
+	}
+
 	dynamicClient, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		klog.Fatalf("Error building dynamic client: %s", err.Error())
@@ -37,19 +84,302 @@ func main() {
 
 	stopCh := util.SetupSignalHandler()
 
-	// Create controllers
-	eventController := controller.NewEventController(kubeClient)
-	statusController := controller.NewStatusController(kubeClient, dynamicClient)
-
-	// Run the event controller
+	// Cancelling the context on a termination signal stops the workers and releases the lease
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	go func() {
-		if err := eventController.Run(2, stopCh); err != nil {
-			klog.Fatalf("Error running event controller: %s", err.Error())
-		}
+		<-stopCh
+		cancel()
 	}()
 
-	// Run the status controller (blocking)
-	if err := statusController.Run(2, stopCh); err != nil {
-		klog.Fatalf("Error running status controller: %s", err.Error())
+	options := controller.Options{
+		InvolvedObjectEvents:  configuration.InvolvedObjectEvents,
+		ImpersonatedClient:    controller.ImpersonatingClients(cfg),
+		RequireServiceAccount: configuration.Jobs.RequireServiceAccount,
+		WatchNamespaces:       configuration.Watch.Namespaces,
+		DryRun:                configuration.Jobs.DryRun,
+		TriggerHistoryLimit:   int(configuration.Audit.TriggerHistoryLimit),
+		LabelPrefix:           configuration.Jobs.LabelPrefix,
+		AnnotationPrefix:      configuration.Jobs.AnnotationPrefix,
+		EventResyncPeriod:     configuration.EventController.ResyncPeriod.Duration,
+		StatusResyncPeriod:    configuration.StatusController.ResyncPeriod.Duration,
+	}
+	if configuration.Watch.Namespaced && len(options.WatchNamespaces) == 0 {
+		options.WatchNamespaces = []string{configuration.LeaderElection.ResourceNamespace}
+	}
+	if endpoint := configuration.Tracing.OTLPEndpoint; endpoint != "" {
+		shutdown, err := tracing.Setup(ctx, tracing.Config{
+			Endpoint:    endpoint,
+			Insecure:    configuration.Tracing.OTLPInsecure,
+			SampleRatio: configuration.Tracing.SampleRatio,
+		})
+		if err != nil {
+			klog.Fatalf("Error setting up tracing: %s", err.Error())
+		}
+		defer shutdown(context.Background())
+		klog.InfoS("Exporting trigger spans", "endpoint", endpoint)
+	}
+	if path := configuration.Audit.LogPath; path != "" {
+		auditLog, err := audit.Open(path)
+		if err != nil {
+			klog.Fatalf("Error opening audit log: %s", err.Error())
+		}
+		defer auditLog.Close()
+		options.AuditLog = auditLog
+	}
+	if !options.RequireServiceAccount {
+		klog.Warning("Templates without a serviceAccountName create jobs with the controller's own permissions, " +
+			"set --require-service-account to only create jobs as the templates' service accounts")
+	}
+	if options.DryRun {
+		klog.InfoS("Running in dry-run mode, jobs are only created and deleted server-side")
+	}
+	if len(options.WatchNamespaces) > 0 {
+		klog.InfoS("Watching namespaces", "namespaces", options.WatchNamespaces)
+	}
+	if configuration.Jobs.DefaultTTLSecondsAfterFinished >= 0 {
+		ttl := configuration.Jobs.DefaultTTLSecondsAfterFinished
+		options.DefaultJobTTLSeconds = &ttl
+	}
+
+	if configuration.Jobs.DefaultBackoffLimit >= 0 {
+		backoffLimit := configuration.Jobs.DefaultBackoffLimit
+		options.DefaultJobBackoffLimit = &backoffLimit
+	}
+
+	// The defaulting webhook stores the same defaults in the templates so they show in their spec
+	defaults := webhook.Defaults{
+		TTLSecondsAfterFinished: options.DefaultJobTTLSeconds,
+		BackoffLimit:            options.DefaultJobBackoffLimit,
+	}
+
+	// Create the controllers of the enabled trigger sources
+	healthChecks := map[string]health.Check{}
+	readyChecks := map[string]health.Check{}
+	var eventController *controller.EventController
+	if configuration.HasTriggerSource(config.EventTriggerSource) {
+		eventController = controller.NewEventControllerWithOptions(kubeClient, kubananaClient, options)
+		healthChecks["event-controller"] = eventController.Healthy
+		readyChecks["event-informer"] = health.SyncCheck(eventController.HasSynced)
+	}
+	var statusController *controller.StatusController
+	if configuration.HasTriggerSource(config.StatusTriggerSource) {
+		statusController = controller.NewStatusControllerWithOptions(kubeClient, kubananaClient, dynamicClient, options)
+		healthChecks["status-controller"] = statusController.Healthy
+		readyChecks["status-informers"] = health.SyncCheck(statusController.HasSynced)
+	}
+	historyController := controller.NewHistoryControllerWithOptions(kubeClient, kubananaClient, configuration.Jobs.HistoryCleanupInterval.Duration, options)
+
+	// Serve metrics on every replica, standbys report their informer caches too
+	if configuration.MetricsBindAddress != "0" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		go serve("metrics", configuration.MetricsBindAddress, mux)
+	}
+
+	// Standbys are ready once their caches are warm
+	if configuration.HealthProbeBindAddress != "0" {
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", health.Handler(healthChecks))
+		mux.Handle("/readyz", health.Handler(readyChecks))
+		go serve("health probes", configuration.HealthProbeBindAddress, mux)
+	}
+
+	// Serve webhooks on every replica, admission doesn't depend on the lease
+	if configuration.Webhooks.Enabled {
+		server, err := newWebhookServer(ctx, kubeClient, dynamicClient, defaults, configuration.Webhooks)
+		if err != nil {
+			klog.Fatalf("Error setting up webhooks: %s", err.Error())
+		}
+		go func() {
+			if err := server.Run(stopCh); err != nil {
+				klog.Fatalf("Error serving webhooks: %s", err.Error())
+			}
+		}()
+	}
+
+	// Warm up the caches, standbys keep them in sync while waiting for the lease
+	if eventController != nil {
+		if err := eventController.Start(stopCh); err != nil {
+			klog.Fatalf("Error starting event controller: %s", err.Error())
+		}
+	}
+	if statusController != nil {
+		if err := statusController.Start(stopCh); err != nil {
+			klog.Fatalf("Error starting status controller: %s", err.Error())
+		}
+	}
+
+	run := func(ctx context.Context) {
+		// Run the job history controller
+		go historyController.Run(ctx.Done())
+
+		// Run the workers of the enabled controllers until the context is done
+		var workers wait.Group
+		if eventController != nil {
+			workers.Start(func() {
+				eventController.RunWorkers(int(configuration.EventController.Workers), ctx.Done())
+			})
+		}
+		if statusController != nil {
+			workers.Start(func() {
+				statusController.RunWorkers(int(configuration.StatusController.Workers), ctx.Done())
+			})
+		}
+		workers.Wait()
+	}
+
+	election := configuration.LeaderElection
+	if !election.LeaderElect {
+		run(ctx)
+		return
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Fatalf("Error getting hostname: %s", err.Error())
+	}
+	identity := hostname + "_" + string(uuid.NewUUID())
+
+	lock := &observedLeaseLock{Interface: &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      election.ResourceName,
+			Namespace: election.ResourceNamespace,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}}
+
+	klog.InfoS("Waiting to acquire lease", "lease", klog.KRef(election.ResourceNamespace, election.ResourceName), "identity", identity)
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   election.LeaseDuration.Duration,
+		RenewDeadline:   election.RenewDeadline.Duration,
+		RetryPeriod:     election.RetryPeriod.Duration,
+		ReleaseOnCancel: true,
+		Name:            election.ResourceName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.InfoS("Acquired lease, processing triggers", "lease", klog.KRef(election.ResourceNamespace, election.ResourceName))
+
+				// Replay the triggers that arrived after the previous leader last renewed or released the lease.
+				// Without a previous leader, the controllers replay the triggers since they were started.
+				if renewed := lock.previousRenewTime(); !renewed.IsZero() {
+					if eventController != nil {
+						eventController.ReplaySince(renewed)
+					}
+					if statusController != nil {
+						statusController.ReplaySince(renewed)
+					}
+				}
+				run(ctx)
+			},
+			OnStoppedLeading: func() {
+				select {
+				case <-ctx.Done():
+					klog.InfoS("Released lease on shutdown")
+				default:
+					// Workers may still be finishing triggers, so don't risk running next to a new leader
+					klog.Fatalf("Lost lease %s/%s", election.ResourceNamespace, election.ResourceName)
+				}
+			},
+			OnNewLeader: func(current string) {
+				if current != identity {
+					klog.InfoS("Lease is held by another replica", "lease", klog.KRef(election.ResourceNamespace, election.ResourceName), "holder", current)
+				}
+			},
+		},
+	})
+}
+
+// newWebhookServer sets up the certificates of the webhook server, generating them unless certDir is set,
+// and the CRD conversion
+func newWebhookServer(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	defaults webhook.Defaults,
+	webhooks config.WebhookConfiguration) (*webhook.Server, error) {
+
+	var certs *webhook.Certificates
+	var err error
+	if webhooks.CertDir != "" {
+		certs, err = webhook.LoadCertificates(webhooks.CertDir)
+	} else {
+		certs, err = webhook.EnsureCertificates(ctx, kubeClient, webhooks.ServiceNamespace, webhooks.SecretName, webhooks.ServiceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Externally managed certificates are expected to be injected by their issuer
+	if webhooks.CertDir == "" && webhooks.ConfigName != "" {
+		if err := webhook.InjectCABundle(ctx, kubeClient, webhooks.ConfigName, certs.CACert); err != nil {
+			return nil, fmt.Errorf("failed to inject CA bundle: %w", err)
+		}
+	}
+
+	// v1beta1 is only served once the API server can convert it to the stored v1alpha1
+	if webhooks.CRDName != "" {
+		if err := webhook.ConfigureConversion(ctx, dynamicClient, webhooks.CRDName, webhooks.ServiceNamespace,
+			webhooks.ServiceName, certs.CACert); err != nil {
+			return nil, fmt.Errorf("failed to configure CRD conversion: %w", err)
+		}
+	}
+
+	cert, err := certs.TLSCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook certificate: %w", err)
+	}
+
+	return webhook.NewServer(webhooks.BindAddress, cert, defaults, webhook.NamespaceLabelsFromClient(kubeClient)), nil
+}
+
+// newJSONLogger returns a logger writing one JSON object per line to stderr, at the verbosity set with -v
+func newJSONLogger() logr.Logger {
+	verbosity, _ := strconv.Atoi(flag.Lookup("v").Value.String())
+	return funcr.NewJSON(func(obj string) {
+		fmt.Fprintln(os.Stderr, obj)
+	}, funcr.Options{LogTimestamp: true, Verbosity: verbosity})
+}
+
+// serve serves handler on addr
+func serve(name, addr string, handler http.Handler) {
+	klog.InfoS("Serving endpoint", "endpoint", name, "address", addr)
+	if err := http.ListenAndServe(addr, handler); err != nil {
+		klog.ErrorS(err, "Error serving endpoint", "endpoint", name)
 	}
 }
+
+// observedLeaseLock remembers when another replica last renewed the lease, so a replica that takes
+// over knows from when on the previous leader stopped handling triggers
+type observedLeaseLock struct {
+	resourcelock.Interface
+
+	mu        sync.Mutex
+	renewTime time.Time
+}
+
+// Get gets the lease record, remembering its renew time while another replica holds it. A released
+// lease has no holder and the time it was released as its renew time.
+func (l *observedLeaseLock) Get(ctx context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	record, raw, err := l.Interface.Get(ctx)
+	if err == nil && record.HolderIdentity != l.Identity() {
+		l.mu.Lock()
+		l.renewTime = record.RenewTime.Time
+		l.mu.Unlock()
+	}
+
+	return record, raw, err
+}
+
+// previousRenewTime returns when the previous leader last renewed the lease, zero if there was none
+func (l *observedLeaseLock) previousRenewTime() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.renewTime
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/roshbhatia/kubanana/pkg/plugin"
+	"github.com/roshbhatia/kubanana/pkg/util"
+)
+
+func main() {
+	stopCh := util.SetupSignalHandler()
+
+	// Cancelling the context on a termination signal stops following logs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	p := &plugin.Plugin{Out: os.Stdout, Connect: plugin.Connect}
+	if err := p.Run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
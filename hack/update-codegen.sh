@@ -4,11 +4,56 @@ set -o errexit
 set -o nounset
 set -o pipefail
 
-SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
-CODEGEN_PKG=${CODEGEN_PKG:-$(cd "${SCRIPT_ROOT}"; ls -d -1 ./vendor/k8s.io/code-generator 2>/dev/null || echo ../code-generator)}
-
-bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy,client,informer,lister" \
-  github.com/roshbhatia/kubanana/pkg/client github.com/roshbhatia/kubanana/pkg/apis \
-  kubanana:v1alpha1 \
-  --output-base "$(dirname "${BASH_SOURCE[0]}")/../../.." \
-  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
\ No newline at end of file
+# Generates deepcopy functions, apply configurations, the versioned clientset with its fakes,
+# listers and informers for the kubanana API group. The generators are installed by
+# `make install-tools`.
+
+SCRIPT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+GOBIN=${GOBIN:-$(go env GOPATH)/bin}
+MODULE=github.com/roshbhatia/kubanana
+HEADER="${SCRIPT_ROOT}/hack/boilerplate.go.txt"
+INPUT_DIRS="${MODULE}/pkg/apis/kubanana/v1alpha1,${MODULE}/pkg/apis/kubanana/v1beta1"
+
+# The generators write GOPATH style, so generate into a temporary tree and copy the result back
+OUTPUT_BASE=$(mktemp -d)
+trap 'rm -rf "${OUTPUT_BASE}"' EXIT
+
+cd "${SCRIPT_ROOT}"
+
+"${GOBIN}/deepcopy-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  -O zz_generated.deepcopy \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT_BASE}"
+
+"${GOBIN}/applyconfiguration-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-package "${MODULE}/pkg/client/applyconfiguration" \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT_BASE}"
+
+"${GOBIN}/client-gen" \
+  --clientset-name versioned \
+  --input-base "" \
+  --input "${INPUT_DIRS}" \
+  --output-package "${MODULE}/pkg/client/clientset" \
+  --apply-configuration-package "${MODULE}/pkg/client/applyconfiguration" \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT_BASE}"
+
+"${GOBIN}/lister-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-package "${MODULE}/pkg/client/listers" \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT_BASE}"
+
+"${GOBIN}/informer-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --versioned-clientset-package "${MODULE}/pkg/client/clientset/versioned" \
+  --listers-package "${MODULE}/pkg/client/listers" \
+  --output-package "${MODULE}/pkg/client/informers" \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT_BASE}"
+
+rm -rf "${SCRIPT_ROOT}/pkg/client"
+cp -r "${OUTPUT_BASE}/${MODULE}/pkg/." "${SCRIPT_ROOT}/pkg/"
@@ -0,0 +1,5 @@
+package v1alpha1
+
+// Hub marks v1alpha1 as the version other versions of EventTriggeredJob convert through. It is
+// also the storage version, so existing templates don't need to be migrated.
+func (*EventTriggeredJob) Hub() {}
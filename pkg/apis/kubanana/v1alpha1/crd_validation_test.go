@@ -230,3 +230,106 @@ func findRootDir(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestCRDCopiesInSync(t *testing.T) {
+	rootDir := findRootDir(t)
+	generated, err := os.ReadFile(filepath.Join(rootDir, "deploy", "crds", "kubanana.roshanbhatia.com_eventtriggeredjobs.yaml"))
+	if err != nil {
+		t.Fatalf("Error reading generated CRD: %v", err)
+	}
+
+	// Copies are refreshed by `make manifests`
+	for _, copyPath := range []string{
+		filepath.Join("charts", "kubanana", "crds", "kubanana.roshanbhatia.com_eventtriggeredjobs.yaml"),
+		filepath.Join("chainsaw", "setup", "kubanana-crd.yaml"),
+	} {
+		content, err := os.ReadFile(filepath.Join(rootDir, copyPath))
+		if err != nil {
+			t.Fatalf("Error reading CRD copy %s: %v", copyPath, err)
+		}
+		if string(content) != string(generated) {
+			t.Errorf("CRD copy %s is out of sync with deploy/crds, run make manifests", copyPath)
+		}
+	}
+}
+
+func TestCRDSchema(t *testing.T) {
+	crdBytes, err := os.ReadFile(filepath.Join(findRootDir(t), "deploy", "crds", "kubanana.roshanbhatia.com_eventtriggeredjobs.yaml"))
+	if err != nil {
+		t.Fatalf("Error reading CRD file: %v", err)
+	}
+
+	var crd struct {
+		Spec struct {
+			Versions []struct {
+				Name                     string        `yaml:"name"`
+				Served                   bool          `yaml:"served"`
+				Storage                  bool          `yaml:"storage"`
+				AdditionalPrinterColumns []interface{} `yaml:"additionalPrinterColumns"`
+				Subresources             struct {
+					Status *map[string]interface{} `yaml:"status"`
+				} `yaml:"subresources"`
+				Schema struct {
+					OpenAPIV3Schema struct {
+						Properties struct {
+							Spec struct {
+								Validations []struct {
+									Rule    string `yaml:"rule"`
+									Message string `yaml:"message"`
+								} `yaml:"x-kubernetes-validations"`
+								Properties map[string]struct {
+									Enum        []string `yaml:"enum"`
+									Validations []struct {
+										Rule string `yaml:"rule"`
+									} `yaml:"x-kubernetes-validations"`
+								} `yaml:"properties"`
+							} `yaml:"spec"`
+						} `yaml:"properties"`
+					} `yaml:"openAPIV3Schema"`
+				} `yaml:"schema"`
+			} `yaml:"versions"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(crdBytes, &crd); err != nil {
+		t.Fatalf("Error parsing CRD YAML: %v", err)
+	}
+
+	if len(crd.Spec.Versions) != 2 || crd.Spec.Versions[0].Name != "v1alpha1" || crd.Spec.Versions[1].Name != "v1beta1" {
+		t.Fatalf("Expected v1alpha1 and v1beta1 versions, got %+v", crd.Spec.Versions)
+	}
+	version := crd.Spec.Versions[0]
+
+	// v1beta1 is served by the controller once the conversion webhook is configured
+	if !version.Served || !version.Storage {
+		t.Error("Expected v1alpha1 to be the served storage version")
+	}
+	if beta := crd.Spec.Versions[1]; beta.Served || beta.Storage {
+		t.Error("Expected v1beta1 to be neither served nor stored")
+	}
+
+	if version.Subresources.Status == nil {
+		t.Error("Expected the status subresource to be enabled")
+	}
+	if len(version.AdditionalPrinterColumns) == 0 {
+		t.Error("Expected printer columns")
+	}
+
+	spec := version.Schema.OpenAPIV3Schema.Properties.Spec
+	if len(spec.Validations) != 1 || spec.Validations[0].Rule != "has(self.eventSelector) != has(self.statusSelector)" {
+		t.Errorf("Expected the exactly one selector rule on spec, got %+v", spec.Validations)
+	}
+	if len(spec.Properties["eventSelector"].Validations) != 1 {
+		t.Errorf("Expected the eventTypes rule on eventSelector, got %+v", spec.Properties["eventSelector"].Validations)
+	}
+
+	for property, expected := range map[string][]string{
+		"concurrencyPolicy":  {"Allow", "Forbid", "Replace"},
+		"concurrencyScope":   {"Template", "Resource"},
+		"suspendPolicy":      {"Drop", "QueueLatest"},
+		"jobNamespacePolicy": {"Template", "Resource"},
+	} {
+		if enum := spec.Properties[property].Enum; len(enum) != len(expected) {
+			t.Errorf("Expected enum %v for %s, got %v", expected, property, enum)
+		}
+	}
+}
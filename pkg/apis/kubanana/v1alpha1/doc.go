@@ -0,0 +1,4 @@
+// Package v1alpha1 contains the v1alpha1 version of the kubanana.roshanbhatia.com API
+// +k8s:deepcopy-gen=package
+// +groupName=kubanana.roshanbhatia.com
+package v1alpha1
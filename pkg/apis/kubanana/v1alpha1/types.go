@@ -2,11 +2,22 @@ package v1alpha1
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=etj
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Event Kind",type=string,JSONPath=`.spec.eventSelector.resourceKind`
+// +kubebuilder:printcolumn:name="Status Kind",type=string,JSONPath=`.spec.statusSelector.resourceKind`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Jobs",type=integer,JSONPath=`.status.jobsCreated`
+// +kubebuilder:printcolumn:name="Last Triggered",type=date,JSONPath=`.status.lastTriggeredTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // EventTriggeredJob defines a job template that gets executed when specific Kubernetes events are fired
 type EventTriggeredJob struct {
@@ -18,6 +29,7 @@ type EventTriggeredJob struct {
 }
 
 // EventTriggeredJobSpec defines the specification for an EventTriggeredJob
+// +kubebuilder:validation:XValidation:rule="has(self.eventSelector) != has(self.statusSelector)",message="exactly one of eventSelector or statusSelector must be set"
 type EventTriggeredJobSpec struct {
 	// EventSelector specifies which events should trigger job creation
 	// +optional
@@ -28,12 +40,176 @@ type EventTriggeredJobSpec struct {
 	StatusSelector *StatusSelector `json:"statusSelector,omitempty"`
 
 	// JobTemplate is the template for the job to be created when an event is triggered
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
 	JobTemplate batchv1.JobTemplateSpec `json:"jobTemplate"`
+
+	// ConcurrencyPolicy specifies how to treat a new trigger while a previously created job is still active
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// ConcurrencyScope specifies which jobs count as previous jobs for the ConcurrencyPolicy (default: "Template")
+	// +optional
+	ConcurrencyScope ConcurrencyScope `json:"concurrencyScope,omitempty"`
+
+	// SuccessfulJobsHistoryLimit is the number of successfully finished jobs to keep (default: unlimited)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// FailedJobsHistoryLimit is the number of failed jobs to keep (default: unlimited)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// RateLimit limits how often jobs are created for this template
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Debounce collects bursts of matching triggers into a single job
+	// +optional
+	Debounce *Debounce `json:"debounce,omitempty"`
+
+	// Suspend pauses job creation for this template without deleting it
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// SuspendPolicy specifies what happens to triggers that arrive while the template is suspended (default: "Drop")
+	// +optional
+	SuspendPolicy SuspendPolicy `json:"suspendPolicy,omitempty"`
+
+	// AllowSelfTrigger lets objects created by Kubanana itself, such as the pods of created jobs,
+	// trigger this template. They are ignored by default to prevent endless loops.
+	// +optional
+	AllowSelfTrigger bool `json:"allowSelfTrigger,omitempty"`
+
+	// MaxTriggerDepth limits how many jobs can be chained through self triggers (default: 3)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxTriggerDepth *int32 `json:"maxTriggerDepth,omitempty"`
+
+	// JobNamespacePolicy specifies the namespace jobs are created in. By default jobs of event triggers
+	// are created in the resource's namespace and jobs of status triggers in the template's namespace.
+	// +optional
+	JobNamespacePolicy JobNamespacePolicy `json:"jobNamespacePolicy,omitempty"`
+
+	// ServiceAccountName is a service account in the template's namespace that the controller
+	// impersonates to create jobs. The jobs run in the template's namespace and their pods must run as
+	// a service account of the same name.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// DryRun makes the controller create this template's jobs with a server-side dry run, so they
+	// are validated and recorded in the status but never persisted
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// JobNamespacePolicy describes which namespace created jobs run in
+// +kubebuilder:validation:Enum=Template;Resource
+type JobNamespacePolicy string
+
+const (
+	// TemplateJobNamespacePolicy creates jobs in the namespace of the template
+	TemplateJobNamespacePolicy JobNamespacePolicy = "Template"
+
+	// ResourceJobNamespacePolicy creates jobs in the namespace of the triggering resource. Jobs for
+	// cluster-scoped resources are created in the template's namespace. Owner references can't
+	// cross namespaces, so jobs in another namespace are linked to the template by labels only.
+	ResourceJobNamespacePolicy JobNamespacePolicy = "Resource"
+)
+
+// SuspendPolicy describes how triggers are handled while a template is suspended
+// +kubebuilder:validation:Enum=Drop;QueueLatest
+type SuspendPolicy string
+
+const (
+	// DropSuspendPolicy drops triggers that arrive while the template is suspended (default)
+	DropSuspendPolicy SuspendPolicy = "Drop"
+
+	// QueueLatestSuspendPolicy keeps the most recent trigger and runs it once the template is resumed
+	QueueLatestSuspendPolicy SuspendPolicy = "QueueLatest"
+)
+
+const (
+	// ConditionSuspended is the condition type set while a template is suspended
+	ConditionSuspended = "Suspended"
+)
+
+// RateLimit defines guardrails against creating too many jobs in a short time
+type RateLimit struct {
+	// MaxJobs is the maximum number of jobs created for the template per Interval (0 means unlimited)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobs int32 `json:"maxJobs,omitempty"`
+
+	// Interval is the time window MaxJobs applies to (default: "1m")
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ResourceCooldown is the minimum time between two jobs triggered by the same resource
+	// +optional
+	ResourceCooldown *metav1.Duration `json:"resourceCooldown,omitempty"`
+}
+
+// ConcurrencyPolicy describes how concurrent jobs for the same template are handled
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows jobs to run concurrently (default)
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+
+	// ForbidConcurrent skips a new trigger if a previous job is still active
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+
+	// ReplaceConcurrent deletes the currently active job and replaces it with a new one
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// ConcurrencyScope describes which previously created jobs are considered by the ConcurrencyPolicy
+// +kubebuilder:validation:Enum=Template;Resource
+type ConcurrencyScope string
+
+const (
+	// TemplateConcurrencyScope considers every job created by the template (default)
+	TemplateConcurrencyScope ConcurrencyScope = "Template"
+
+	// ResourceConcurrencyScope considers only jobs created for the same triggering resource
+	ResourceConcurrencyScope ConcurrencyScope = "Resource"
+)
+
+// Debounce defines how bursty triggers are coalesced into a single job
+type Debounce struct {
+	// Window is how long triggers are collected after the first one before the job is created
+	Window metav1.Duration `json:"window"`
+
+	// Key specifies how triggers are grouped into a batch (default: "Template")
+	// +optional
+	Key DebounceKey `json:"key,omitempty"`
 }
 
+// DebounceKey describes how debounced triggers are grouped
+// +kubebuilder:validation:Enum=Template;Resource;Owner
+type DebounceKey string
+
+const (
+	// TemplateDebounceKey collects all triggers of the template into one batch (default)
+	TemplateDebounceKey DebounceKey = "Template"
+
+	// ResourceDebounceKey collects triggers per triggering resource
+	ResourceDebounceKey DebounceKey = "Resource"
+
+	// OwnerDebounceKey collects triggers per controlling owner of the triggering resource (e.g., a Deployment)
+	OwnerDebounceKey DebounceKey = "Owner"
+)
+
 // EventSelector defines criteria for selecting which events trigger job creation
+// +kubebuilder:validation:XValidation:rule="self.eventTypes.all(t, t in ['CREATE', 'UPDATE', 'DELETE'])",message="eventTypes must be in CREATE/UPDATE/DELETE"
 type EventSelector struct {
 	// ResourceKind is the kind of the resource to watch (e.g., "Pod", "Deployment")
+	// +kubebuilder:validation:MinLength=1
 	ResourceKind string `json:"resourceKind"`
 
 	// NamePattern is a glob pattern to filter resource names
@@ -49,24 +225,102 @@ type EventSelector struct {
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
 	// EventTypes are the types of events to watch for (e.g., "CREATE", "UPDATE", "DELETE")
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=CREATE;UPDATE;DELETE
 	EventTypes []string `json:"eventTypes"`
 }
 
 // EventTriggeredJobStatus defines the observed state of EventTriggeredJob
 type EventTriggeredJobStatus struct {
-	// JobsCreated is the number of jobs created by this template
+	// JobsCreated is the number of jobs created by this template. Dry-run jobs are counted in DryRunJobs
 	JobsCreated int64 `json:"jobsCreated"`
 
-	// LastTriggeredTime is the last time a job was triggered
+	// LastTriggeredTime is the last time the template created a job
 	// +optional
 	LastTriggeredTime *metav1.Time `json:"lastTriggeredTime,omitempty"`
 
 	// Conditions represent the latest available observations of the template's state
 	// +optional
+	// +listType=map
+	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// TriggersDropped is the number of matching triggers that didn't create a job
+	// +optional
+	TriggersDropped int64 `json:"triggersDropped,omitempty"`
+
+	// LastDropReason is the reason the most recent trigger was dropped (e.g., "RateLimited")
+	// +optional
+	LastDropReason string `json:"lastDropReason,omitempty"`
+
+	// DryRunJobs is the number of jobs a dry run would have created
+	// +optional
+	DryRunJobs int64 `json:"dryRunJobs,omitempty"`
+
+	// LastDryRunJob is the namespace and name of the most recent job a dry run would have created
+	// +optional
+	LastDryRunJob string `json:"lastDryRunJob,omitempty"`
+
+	// RecentTriggers are the outcomes of the most recent triggers that matched the template, newest
+	// first. The controller only keeps them if it runs with a trigger history limit.
+	// +optional
+	RecentTriggers []TriggerRecord `json:"recentTriggers,omitempty"`
 }
 
-// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// TriggerOutcome describes what happened to a trigger that matched a template
+// +kubebuilder:validation:Enum=JobCreated;JobDryRun;JobFailed;Dropped;Queued;Debounced
+type TriggerOutcome string
+
+const (
+	// JobCreatedTriggerOutcome means a job was created for the trigger
+	JobCreatedTriggerOutcome TriggerOutcome = "JobCreated"
+
+	// JobDryRunTriggerOutcome means a job was only created with a server-side dry run
+	JobDryRunTriggerOutcome TriggerOutcome = "JobDryRun"
+
+	// JobFailedTriggerOutcome means the job for the trigger couldn't be created
+	JobFailedTriggerOutcome TriggerOutcome = "JobFailed"
+
+	// DroppedTriggerOutcome means the trigger was dropped, e.g. by a rate limit
+	DroppedTriggerOutcome TriggerOutcome = "Dropped"
+
+	// QueuedTriggerOutcome means the trigger was queued until the suspended template is resumed
+	QueuedTriggerOutcome TriggerOutcome = "Queued"
+
+	// DebouncedTriggerOutcome means the trigger was added to a debounce batch
+	DebouncedTriggerOutcome TriggerOutcome = "Debounced"
+)
+
+// TriggerRecord records the decision taken for a trigger that matched a template
+type TriggerRecord struct {
+	// Time is when the decision was taken
+	Time metav1.Time `json:"time"`
+
+	// Trigger is the kind of trigger, "event" or "status"
+	Trigger string `json:"trigger"`
+
+	// EventType is the event type of event triggers (e.g., "CREATE")
+	// +optional
+	EventType string `json:"eventType,omitempty"`
+
+	// Resource is the object that triggered the template, with the UID and resourceVersion it had
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Match lists the selector fields that matched the resource and why
+	// +optional
+	Match []string `json:"match,omitempty"`
+
+	// Outcome is what happened to the trigger
+	Outcome TriggerOutcome `json:"outcome"`
+
+	// Job is the namespace and name of the job created for the trigger
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Reason explains why the trigger didn't create a job (e.g., "RateLimited")
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
 
 // StatusCondition describes a condition that should match a resource's status
 type StatusCondition struct {
@@ -78,12 +332,14 @@ type StatusCondition struct {
 
 	// Operator specifies how to compare the condition (default: "Equal")
 	// +optional
+	// +kubebuilder:validation:Enum=Equal
 	Operator string `json:"operator,omitempty"`
 }
 
 // StatusSelector defines criteria for selecting resources based on their status conditions
 type StatusSelector struct {
 	// ResourceKind is the kind of the resource to watch (e.g., "Pod", "Deployment")
+	// +kubebuilder:validation:MinLength=1
 	ResourceKind string `json:"resourceKind"`
 
 	// NamePattern is a glob pattern to filter resource names
@@ -99,9 +355,13 @@ type StatusSelector struct {
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
 	// Conditions are the status conditions to match
+	// +kubebuilder:validation:MinItems=1
 	Conditions []StatusCondition `json:"conditions"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
 // EventTriggeredJobList contains a list of EventTriggeredJob
 type EventTriggeredJobList struct {
 	metav1.TypeMeta `json:",inline"`
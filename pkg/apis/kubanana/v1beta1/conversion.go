@@ -0,0 +1,340 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConversionDataAnnotation keeps the parts of a template that the other version can't represent,
+// so converting to v1alpha1 and back doesn't lose them
+const ConversionDataAnnotation = "kubanana.roshanbhatia.com/conversion-data"
+
+// conversionData is stored as JSON in the ConversionDataAnnotation
+type conversionData struct {
+	// Triggers are the original v1beta1 triggers, kept when v1alpha1 merges several of them
+	Triggers []Trigger `json:"triggers,omitempty"`
+}
+
+// ConvertTo converts this template to the v1alpha1 hub version
+func (src *EventTriggeredJob) ConvertTo(dst *v1alpha1.EventTriggeredJob) error {
+	// v1alpha1 templates have exactly one selector, so event and status triggers can't be mixed
+	if mixesTriggerKinds(src.Spec.Triggers) {
+		return fmt.Errorf("template %s/%s mixes event and status triggers, which v1alpha1 can't represent",
+			src.Namespace, src.Name)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.TypeMeta = src.TypeMeta
+	dst.APIVersion = v1alpha1.SchemeGroupVersion.String()
+
+	dst.Spec.EventSelector, dst.Spec.StatusSelector = selectorsFromTriggers(src.Spec.Target, src.Spec.Triggers)
+
+	// Keep the triggers only if they can't be derived from the selectors again
+	data := conversionData{}
+	if !equality.Semantic.DeepEqual(triggersFromSelectors(dst.Spec.EventSelector, dst.Spec.StatusSelector), src.Spec.Triggers) {
+		data.Triggers = src.Spec.Triggers
+	}
+	if err := writeConversionData(&dst.ObjectMeta, data); err != nil {
+		return err
+	}
+
+	dst.Spec.JobTemplate = *src.Spec.JobTemplate.DeepCopy()
+	dst.Spec.ConcurrencyPolicy = v1alpha1.ConcurrencyPolicy(src.Spec.ConcurrencyPolicy)
+	dst.Spec.ConcurrencyScope = v1alpha1.ConcurrencyScope(src.Spec.ConcurrencyScope)
+	dst.Spec.SuccessfulJobsHistoryLimit = copyInt32(src.Spec.SuccessfulJobsHistoryLimit)
+	dst.Spec.FailedJobsHistoryLimit = copyInt32(src.Spec.FailedJobsHistoryLimit)
+	dst.Spec.RateLimit = nil
+	if src.Spec.RateLimit != nil {
+		dst.Spec.RateLimit = &v1alpha1.RateLimit{
+			MaxJobs:          src.Spec.RateLimit.MaxJobs,
+			Interval:         src.Spec.RateLimit.Interval.DeepCopy(),
+			ResourceCooldown: src.Spec.RateLimit.ResourceCooldown.DeepCopy(),
+		}
+	}
+	dst.Spec.Debounce = nil
+	if src.Spec.Debounce != nil {
+		dst.Spec.Debounce = &v1alpha1.Debounce{
+			Window: src.Spec.Debounce.Window,
+			Key:    v1alpha1.DebounceKey(src.Spec.Debounce.Key),
+		}
+	}
+	dst.Spec.Suspend = copyBool(src.Spec.Suspend)
+	dst.Spec.SuspendPolicy = v1alpha1.SuspendPolicy(src.Spec.SuspendPolicy)
+	dst.Spec.AllowSelfTrigger = src.Spec.AllowSelfTrigger
+	dst.Spec.MaxTriggerDepth = copyInt32(src.Spec.MaxTriggerDepth)
+	dst.Spec.JobNamespacePolicy = v1alpha1.JobNamespacePolicy(src.Spec.JobNamespacePolicy)
+	dst.Spec.ServiceAccountName = src.Spec.ServiceAccountName
+	dst.Spec.DryRun = src.Spec.DryRun
+
+	dst.Status = v1alpha1.EventTriggeredJobStatus{
+		JobsCreated:       src.Status.JobsCreated,
+		LastTriggeredTime: src.Status.LastTriggeredTime.DeepCopy(),
+		Conditions:        src.Status.DeepCopy().Conditions,
+		TriggersDropped:   src.Status.TriggersDropped,
+		LastDropReason:    src.Status.LastDropReason,
+		DryRunJobs:        src.Status.DryRunJobs,
+		LastDryRunJob:     src.Status.LastDryRunJob,
+	}
+	for _, record := range src.Status.RecentTriggers {
+		dst.Status.RecentTriggers = append(dst.Status.RecentTriggers, v1alpha1.TriggerRecord{
+			Time:      record.Time,
+			Trigger:   record.Trigger,
+			EventType: record.EventType,
+			Resource:  record.Resource,
+			Match:     copyStrings(record.Match),
+			Outcome:   v1alpha1.TriggerOutcome(record.Outcome),
+			Job:       record.Job,
+			Reason:    record.Reason,
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts a template from the v1alpha1 hub version to this version
+func (dst *EventTriggeredJob) ConvertFrom(src *v1alpha1.EventTriggeredJob) error {
+	data, err := readConversionData(src.Annotations)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.TypeMeta = src.TypeMeta
+	dst.APIVersion = SchemeGroupVersion.String()
+
+	switch {
+	case src.Spec.EventSelector != nil:
+		dst.Spec.Target = ResourceSelector{
+			Kind:             src.Spec.EventSelector.ResourceKind,
+			NamePattern:      src.Spec.EventSelector.NamePattern,
+			NamespacePattern: src.Spec.EventSelector.NamespacePattern,
+			LabelSelector:    src.Spec.EventSelector.LabelSelector.DeepCopy(),
+		}
+	case src.Spec.StatusSelector != nil:
+		dst.Spec.Target = ResourceSelector{
+			Kind:             src.Spec.StatusSelector.ResourceKind,
+			NamePattern:      src.Spec.StatusSelector.NamePattern,
+			NamespacePattern: src.Spec.StatusSelector.NamespacePattern,
+			LabelSelector:    src.Spec.StatusSelector.LabelSelector.DeepCopy(),
+		}
+	default:
+		dst.Spec.Target = ResourceSelector{}
+	}
+
+	// Stored triggers are only used while they still match the selectors, which may have been
+	// changed through v1alpha1 since
+	dst.Spec.Triggers = triggersFromSelectors(src.Spec.EventSelector, src.Spec.StatusSelector)
+	if len(data.Triggers) > 0 {
+		eventSelector, statusSelector := selectorsFromTriggers(dst.Spec.Target, data.Triggers)
+		if equality.Semantic.DeepEqual(eventSelector, src.Spec.EventSelector) &&
+			equality.Semantic.DeepEqual(statusSelector, src.Spec.StatusSelector) {
+			dst.Spec.Triggers = data.Triggers
+		}
+	}
+
+	if err := writeConversionData(&dst.ObjectMeta, conversionData{}); err != nil {
+		return err
+	}
+
+	dst.Spec.JobTemplate = *src.Spec.JobTemplate.DeepCopy()
+	dst.Spec.ConcurrencyPolicy = ConcurrencyPolicy(src.Spec.ConcurrencyPolicy)
+	dst.Spec.ConcurrencyScope = ConcurrencyScope(src.Spec.ConcurrencyScope)
+	dst.Spec.SuccessfulJobsHistoryLimit = copyInt32(src.Spec.SuccessfulJobsHistoryLimit)
+	dst.Spec.FailedJobsHistoryLimit = copyInt32(src.Spec.FailedJobsHistoryLimit)
+	dst.Spec.RateLimit = nil
+	if src.Spec.RateLimit != nil {
+		dst.Spec.RateLimit = &RateLimit{
+			MaxJobs:          src.Spec.RateLimit.MaxJobs,
+			Interval:         src.Spec.RateLimit.Interval.DeepCopy(),
+			ResourceCooldown: src.Spec.RateLimit.ResourceCooldown.DeepCopy(),
+		}
+	}
+	dst.Spec.Debounce = nil
+	if src.Spec.Debounce != nil {
+		dst.Spec.Debounce = &Debounce{
+			Window: src.Spec.Debounce.Window,
+			Key:    DebounceKey(src.Spec.Debounce.Key),
+		}
+	}
+	dst.Spec.Suspend = copyBool(src.Spec.Suspend)
+	dst.Spec.SuspendPolicy = SuspendPolicy(src.Spec.SuspendPolicy)
+	dst.Spec.AllowSelfTrigger = src.Spec.AllowSelfTrigger
+	dst.Spec.MaxTriggerDepth = copyInt32(src.Spec.MaxTriggerDepth)
+	dst.Spec.JobNamespacePolicy = JobNamespacePolicy(src.Spec.JobNamespacePolicy)
+	dst.Spec.ServiceAccountName = src.Spec.ServiceAccountName
+	dst.Spec.DryRun = src.Spec.DryRun
+
+	dst.Status = EventTriggeredJobStatus{
+		JobsCreated:       src.Status.JobsCreated,
+		LastTriggeredTime: src.Status.LastTriggeredTime.DeepCopy(),
+		Conditions:        src.Status.DeepCopy().Conditions,
+		TriggersDropped:   src.Status.TriggersDropped,
+		LastDropReason:    src.Status.LastDropReason,
+		DryRunJobs:        src.Status.DryRunJobs,
+		LastDryRunJob:     src.Status.LastDryRunJob,
+	}
+	for _, record := range src.Status.RecentTriggers {
+		dst.Status.RecentTriggers = append(dst.Status.RecentTriggers, TriggerRecord{
+			Time:      record.Time,
+			Trigger:   record.Trigger,
+			EventType: record.EventType,
+			Resource:  record.Resource,
+			Match:     copyStrings(record.Match),
+			Outcome:   TriggerOutcome(record.Outcome),
+			Job:       record.Job,
+			Reason:    record.Reason,
+		})
+	}
+
+	return nil
+}
+
+// selectorsFromTriggers merges the triggers into v1alpha1 selectors. Event triggers are combined
+// into one event selector matching any of their types.
+func selectorsFromTriggers(target ResourceSelector, triggers []Trigger) (*v1alpha1.EventSelector, *v1alpha1.StatusSelector) {
+
+	var eventSelector *v1alpha1.EventSelector
+	var statusSelector *v1alpha1.StatusSelector
+	for _, trigger := range triggers {
+		if trigger.Event != nil {
+			if eventSelector == nil {
+				eventSelector = &v1alpha1.EventSelector{
+					ResourceKind:     target.Kind,
+					NamePattern:      target.NamePattern,
+					NamespacePattern: target.NamespacePattern,
+					LabelSelector:    target.LabelSelector.DeepCopy(),
+				}
+			}
+			for _, eventType := range trigger.Event.Types {
+				if !containsString(eventSelector.EventTypes, string(eventType)) {
+					eventSelector.EventTypes = append(eventSelector.EventTypes, string(eventType))
+				}
+			}
+		}
+
+		// Only one status trigger is allowed, so there is nothing to merge
+		if trigger.Status != nil && statusSelector == nil {
+			statusSelector = &v1alpha1.StatusSelector{
+				ResourceKind:     target.Kind,
+				NamePattern:      target.NamePattern,
+				NamespacePattern: target.NamespacePattern,
+				LabelSelector:    target.LabelSelector.DeepCopy(),
+			}
+			for _, condition := range trigger.Status.Conditions {
+				statusSelector.Conditions = append(statusSelector.Conditions, v1alpha1.StatusCondition(condition))
+			}
+		}
+	}
+
+	return eventSelector, statusSelector
+}
+
+// triggersFromSelectors returns the triggers matching the same changes as the v1alpha1 selectors
+func triggersFromSelectors(eventSelector *v1alpha1.EventSelector, statusSelector *v1alpha1.StatusSelector) []Trigger {
+	var triggers []Trigger
+	if eventSelector != nil {
+		trigger := &EventTrigger{}
+		for _, eventType := range eventSelector.EventTypes {
+			trigger.Types = append(trigger.Types, EventType(eventType))
+		}
+		triggers = append(triggers, Trigger{Event: trigger})
+	}
+
+	if statusSelector != nil {
+		trigger := &StatusTrigger{}
+		for _, condition := range statusSelector.Conditions {
+			trigger.Conditions = append(trigger.Conditions, StatusCondition(condition))
+		}
+		triggers = append(triggers, Trigger{Status: trigger})
+	}
+
+	return triggers
+}
+
+// readConversionData reads the ConversionDataAnnotation, if set
+func readConversionData(annotations map[string]string) (conversionData, error) {
+	data := conversionData{}
+	value, ok := annotations[ConversionDataAnnotation]
+	if !ok {
+		return data, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return data, fmt.Errorf("invalid %s annotation: %w", ConversionDataAnnotation, err)
+	}
+
+	return data, nil
+}
+
+// writeConversionData sets the ConversionDataAnnotation, or removes it if there is nothing to keep
+func writeConversionData(meta *metav1.ObjectMeta, data conversionData) error {
+	if len(data.Triggers) == 0 {
+		delete(meta.Annotations, ConversionDataAnnotation)
+		if len(meta.Annotations) == 0 {
+			meta.Annotations = nil
+		}
+		return nil
+	}
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s annotation: %w", ConversionDataAnnotation, err)
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ConversionDataAnnotation] = string(value)
+
+	return nil
+}
+
+// mixesTriggerKinds checks if the triggers include both event and status triggers
+func mixesTriggerKinds(triggers []Trigger) bool {
+	var hasEvent, hasStatus bool
+	for _, trigger := range triggers {
+		hasEvent = hasEvent || trigger.Event != nil
+		hasStatus = hasStatus || trigger.Status != nil
+	}
+	return hasEvent && hasStatus
+}
+
+// containsString checks if a string is in a slice
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// copyInt32 returns a copy of an optional int32
+func copyInt32(value *int32) *int32 {
+	if value == nil {
+		return nil
+	}
+	out := *value
+	return &out
+}
+
+// copyBool returns a copy of an optional bool
+func copyBool(value *bool) *bool {
+	if value == nil {
+		return nil
+	}
+	out := *value
+	return &out
+}
+
+// copyStrings returns a copy of a string slice
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
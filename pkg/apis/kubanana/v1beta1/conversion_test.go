@@ -0,0 +1,196 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTemplate creates a v1beta1 template with the given triggers
+func newTemplate(triggers ...Trigger) *EventTriggeredJob {
+	suspend := true
+	limit := int32(3)
+	return &EventTriggeredJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       "EventTriggeredJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template",
+			Namespace: "default",
+			Labels:    map[string]string{"team": "a"},
+		},
+		Spec: EventTriggeredJobSpec{
+			Target: ResourceSelector{
+				Kind:             "Pod",
+				NamePattern:      "web-*",
+				NamespacePattern: "prod-*",
+				LabelSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			Triggers: triggers,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "test", Image: "busybox"}},
+						},
+					},
+				},
+			},
+			ConcurrencyPolicy:      ForbidConcurrent,
+			FailedJobsHistoryLimit: &limit,
+			RateLimit:              &RateLimit{MaxJobs: 5, Interval: &metav1.Duration{Duration: 60e9}},
+			Debounce:               &Debounce{Window: metav1.Duration{Duration: 10e9}, Key: OwnerDebounceKey},
+			Suspend:                &suspend,
+			SuspendPolicy:          QueueLatestSuspendPolicy,
+			JobNamespacePolicy:     ResourceJobNamespacePolicy,
+			ServiceAccountName:     "deployer",
+			DryRun:                 true,
+		},
+		Status: EventTriggeredJobStatus{
+			JobsCreated:     4,
+			TriggersDropped: 1,
+			LastDropReason:  "RateLimited",
+			DryRunJobs:      2,
+			RecentTriggers: []TriggerRecord{{
+				Trigger:  "event",
+				Resource: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"},
+				Outcome:  DroppedTriggerOutcome,
+				Reason:   "RateLimited",
+			}},
+		},
+	}
+}
+
+func eventTrigger(types ...EventType) Trigger {
+	return Trigger{Event: &EventTrigger{Types: types}}
+}
+
+func statusTrigger(conditionType, status string) Trigger {
+	return Trigger{Status: &StatusTrigger{Conditions: []StatusCondition{{Type: conditionType, Status: status}}}}
+}
+
+func TestConvertTo(t *testing.T) {
+	template := newTemplate(eventTrigger(CreateEvent, DeleteEvent))
+
+	hub := &v1alpha1.EventTriggeredJob{}
+	if err := template.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+
+	if hub.APIVersion != "kubanana.roshanbhatia.com/v1alpha1" {
+		t.Errorf("Expected v1alpha1 apiVersion, got %s", hub.APIVersion)
+	}
+	if hub.Spec.EventSelector == nil || hub.Spec.EventSelector.ResourceKind != "Pod" ||
+		hub.Spec.EventSelector.NamePattern != "web-*" || hub.Spec.EventSelector.NamespacePattern != "prod-*" {
+		t.Errorf("Expected event selector on the target, got %+v", hub.Spec.EventSelector)
+	}
+	if len(hub.Spec.EventSelector.EventTypes) != 2 {
+		t.Errorf("Expected event types CREATE and DELETE, got %v", hub.Spec.EventSelector.EventTypes)
+	}
+	if hub.Spec.StatusSelector != nil {
+		t.Errorf("Expected no status selector, got %+v", hub.Spec.StatusSelector)
+	}
+	if _, ok := hub.Annotations[ConversionDataAnnotation]; ok {
+		t.Errorf("Expected no conversion data for triggers v1alpha1 can represent, got %v", hub.Annotations)
+	}
+	if hub.Spec.ConcurrencyPolicy != v1alpha1.ForbidConcurrent || hub.Spec.SuspendPolicy != v1alpha1.QueueLatestSuspendPolicy ||
+		hub.Spec.JobNamespacePolicy != v1alpha1.ResourceJobNamespacePolicy || hub.Spec.Debounce.Key != v1alpha1.OwnerDebounceKey ||
+		hub.Spec.ServiceAccountName != "deployer" || !hub.Spec.DryRun {
+		t.Errorf("Expected policies to be converted, got %+v", hub.Spec)
+	}
+	if hub.Status.JobsCreated != 4 || hub.Status.LastDropReason != "RateLimited" || hub.Status.DryRunJobs != 2 ||
+		len(hub.Status.RecentTriggers) != 1 || hub.Status.RecentTriggers[0].Outcome != v1alpha1.DroppedTriggerOutcome {
+		t.Errorf("Expected status to be converted, got %+v", hub.Status)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	tests := []struct {
+		name           string
+		triggers       []Trigger
+		expectMetadata bool
+	}{
+		{
+			name:     "event trigger",
+			triggers: []Trigger{eventTrigger(CreateEvent)},
+		},
+		{
+			name:     "status trigger",
+			triggers: []Trigger{statusTrigger("Ready", "True")},
+		},
+		{
+			name:           "merged event triggers",
+			triggers:       []Trigger{eventTrigger(CreateEvent), eventTrigger(DeleteEvent)},
+			expectMetadata: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := newTemplate(tt.triggers...)
+
+			hub := &v1alpha1.EventTriggeredJob{}
+			if err := template.ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo failed: %v", err)
+			}
+			if _, ok := hub.Annotations[ConversionDataAnnotation]; ok != tt.expectMetadata {
+				t.Errorf("Expected conversion data %v, got annotations %v", tt.expectMetadata, hub.Annotations)
+			}
+
+			converted := &EventTriggeredJob{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom failed: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(converted, template) {
+				t.Errorf("Expected round trip to keep the template\nwant: %+v\ngot:  %+v", template, converted)
+			}
+		})
+	}
+}
+
+func TestConvertToRejectsMixedTriggers(t *testing.T) {
+	template := newTemplate(eventTrigger(CreateEvent), statusTrigger("Ready", "True"))
+
+	if err := template.ConvertTo(&v1alpha1.EventTriggeredJob{}); err == nil {
+		t.Error("Expected an error for mixed event and status triggers")
+	}
+}
+
+func TestConvertFromIgnoresStaleTriggers(t *testing.T) {
+	template := newTemplate(eventTrigger(CreateEvent), eventTrigger(DeleteEvent))
+
+	hub := &v1alpha1.EventTriggeredJob{}
+	if err := template.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+
+	// The selector was changed through v1alpha1 after the triggers were stored
+	hub.Spec.EventSelector.EventTypes = []string{"UPDATE"}
+
+	converted := &EventTriggeredJob{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	expected := []Trigger{eventTrigger(UpdateEvent)}
+	if !equality.Semantic.DeepEqual(converted.Spec.Triggers, expected) {
+		t.Errorf("Expected triggers %+v, got %+v", expected, converted.Spec.Triggers)
+	}
+	if _, ok := converted.Annotations[ConversionDataAnnotation]; ok {
+		t.Errorf("Expected stale conversion data to be dropped, got %v", converted.Annotations)
+	}
+}
+
+func TestConvertInvalidConversionData(t *testing.T) {
+	hub := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ConversionDataAnnotation: "{"}},
+	}
+
+	if err := (&EventTriggeredJob{}).ConvertFrom(hub); err == nil {
+		t.Error("Expected an error for invalid conversion data")
+	}
+}
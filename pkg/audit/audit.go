@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Record is a trigger decision in the audit log: the template, what triggered it and what happened
+type Record struct {
+	// TemplateNamespace and Template name the EventTriggeredJob that matched
+	TemplateNamespace string    `json:"templateNamespace"`
+	Template          string    `json:"template"`
+	TemplateUID       types.UID `json:"templateUID,omitempty"`
+	// TraceID ties the record to the controller's logs of the same trigger
+	TraceID string `json:"traceID,omitempty"`
+
+	v1alpha1.TriggerRecord
+}
+
+// Log writes audit records as a stream of JSON objects, one per line
+type Log struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewLog creates a log writing to w
+func NewLog(w io.Writer) *Log {
+	return &Log{encoder: json.NewEncoder(w)}
+}
+
+// Open creates a log appending to the file at path, or writing to stdout if path is "-"
+func Open(path string) (*Log, error) {
+	if path == "-" {
+		return NewLog(os.Stdout), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	log := NewLog(file)
+	log.closer = file
+	return log, nil
+}
+
+// Write appends a record to the log
+func (l *Log) Write(record Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.encoder.Encode(record)
+}
+
+// Close closes the file the log writes to, if it opened one
+func (l *Log) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+
+	return l.closer.Close()
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestRecord(reason string) Record {
+	return Record{
+		TemplateNamespace: "default",
+		Template:          "test-template",
+		TriggerRecord: v1alpha1.TriggerRecord{
+			Trigger:  "event",
+			Resource: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1", UID: "1234"},
+			Outcome:  v1alpha1.DroppedTriggerOutcome,
+			Reason:   reason,
+		},
+	}
+}
+
+func TestLogWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLog(&buf)
+
+	for _, reason := range []string{"RateLimited", "Suspended"} {
+		if err := log.Write(newTestRecord(reason)); err != nil {
+			t.Fatalf("Failed to write record: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per record, got %q", buf.String())
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &fields); err != nil {
+		t.Fatalf("Failed to decode record: %v", err)
+	}
+	if fields["template"] != "test-template" || fields["outcome"] != "Dropped" || fields["reason"] != "Suspended" {
+		t.Errorf("Expected the trigger record's fields next to the template, got %v", fields)
+	}
+	if resource, ok := fields["resource"].(map[string]interface{}); !ok || resource["uid"] != "1234" {
+		t.Errorf("Expected the resource with its UID, got %v", fields["resource"])
+	}
+}
+
+func TestOpenAppendsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	for i := 0; i < 2; i++ {
+		log, err := Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open audit log: %v", err)
+		}
+		if err := log.Write(newTestRecord("RateLimited")); err != nil {
+			t.Fatalf("Failed to write record: %v", err)
+		}
+		if err := log.Close(); err != nil {
+			t.Fatalf("Failed to close audit log: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("Expected records to be appended, got %d lines", lines)
+	}
+}
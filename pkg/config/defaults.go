@@ -0,0 +1,50 @@
+package config
+
+import (
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	"github.com/roshbhatia/kubanana/pkg/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewDefault returns the configuration the controller runs with when neither a configuration file
+// nor flags change it
+func NewDefault() *KubananaConfiguration {
+	return &KubananaConfiguration{
+		TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		ClientConnection: ClientConnectionConfiguration{
+			QPS:   20,
+			Burst: 30,
+		},
+		TriggerSources:   []TriggerSource{EventTriggerSource, StatusTriggerSource},
+		EventController:  ControllerConfiguration{Workers: 2},
+		StatusController: ControllerConfiguration{Workers: 2},
+		Jobs: JobConfiguration{
+			DefaultTTLSecondsAfterFinished: 86400,
+			DefaultBackoffLimit:            6,
+			HistoryCleanupInterval:         metav1.Duration{Duration: time.Minute},
+			LabelPrefix:                    controller.DefaultLabelPrefix,
+			AnnotationPrefix:               controller.DefaultAnnotationPrefix,
+		},
+		Webhooks: WebhookConfiguration{
+			BindAddress:      ":9443",
+			SecretName:       "kubanana-webhook-cert",
+			ServiceName:      "kubanana-webhook",
+			ServiceNamespace: "kubanana-system",
+			ConfigName:       "kubanana",
+			CRDName:          webhook.CRDName,
+		},
+		Logging:                LoggingConfiguration{Format: TextLogFormat},
+		Tracing:                TracingConfiguration{SampleRatio: 1},
+		MetricsBindAddress:     ":8080",
+		HealthProbeBindAddress: ":8081",
+		LeaderElection: LeaderElectionConfiguration{
+			ResourceName:      "kubanana-controller",
+			ResourceNamespace: "kubanana-system",
+			LeaseDuration:     metav1.Duration{Duration: 15 * time.Second},
+			RenewDeadline:     metav1.Duration{Duration: 10 * time.Second},
+			RetryPeriod:       metav1.Duration{Duration: 2 * time.Second},
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AddFlags registers the flags that override the configuration file, writing their values into config
+func AddFlags(fs *flag.FlagSet, config *KubananaConfiguration) {
+	fs.StringVar(&config.ClientConnection.Kubeconfig, "kubeconfig", config.ClientConnection.Kubeconfig, "Path to a kubeconfig. Only required if out-of-cluster.")
+	fs.Var((*float32Value)(&config.ClientConnection.QPS), "kube-api-qps", "Requests per second sent to the API server.")
+	fs.Var((*int32Value)(&config.ClientConnection.Burst), "kube-api-burst", "Requests that may be sent to the API server above --kube-api-qps for a short time.")
+	fs.Var((*triggerSourcesValue)(&config.TriggerSources), "trigger-sources", "Comma-separated kinds of triggers that run templates: event, status.")
+	fs.Var((*int32Value)(&config.EventController.Workers), "event-workers", "Number of events processed concurrently.")
+	fs.DurationVar(&config.EventController.ResyncPeriod.Duration, "event-resync-period", config.EventController.ResyncPeriod.Duration, "How often the event controller's informers resync their cache. 0 disables resyncs.")
+	fs.Var((*int32Value)(&config.StatusController.Workers), "status-workers", "Number of status changes processed concurrently.")
+	fs.DurationVar(&config.StatusController.ResyncPeriod.Duration, "status-resync-period", config.StatusController.ResyncPeriod.Duration, "How often the status controller's informers resync their cache. 0 disables resyncs.")
+	fs.Var((*int32Value)(&config.Jobs.DefaultTTLSecondsAfterFinished), "default-job-ttl-seconds", "ttlSecondsAfterFinished applied to created jobs whose template doesn't set one. A negative value disables the default.")
+	fs.Var((*int32Value)(&config.Jobs.DefaultBackoffLimit), "default-job-backoff-limit", "backoffLimit applied to created jobs, and set by the defaulting webhook on job templates, that don't set one. A negative value leaves it to the Job API.")
+	fs.BoolVar(&config.Jobs.RequireServiceAccount, "require-service-account", config.Jobs.RequireServiceAccount, "Only create jobs for EventTriggeredJobs that set spec.serviceAccountName, instead of creating the others with the controller's permissions.")
+	fs.BoolVar(&config.Jobs.DryRun, "dry-run", config.Jobs.DryRun, "Evaluate triggers and record the jobs they would create, but only create and delete jobs with a server-side dry run.")
+	fs.DurationVar(&config.Jobs.HistoryCleanupInterval.Duration, "history-cleanup-interval", config.Jobs.HistoryCleanupInterval.Duration, "How often finished jobs exceeding a template's history limits are pruned.")
+	fs.StringVar(&config.Jobs.LabelPrefix, "label-prefix", config.Jobs.LabelPrefix, "Prefix of the labels set on created jobs.")
+	fs.StringVar(&config.Jobs.AnnotationPrefix, "annotation-prefix", config.Jobs.AnnotationPrefix, "Prefix of the annotations set on created jobs and their pods.")
+	fs.Var((*namespacesValue)(&config.Watch.Namespaces), "watch-namespaces", "Comma-separated namespaces to watch events, resources and EventTriggeredJobs in. Templates in other namespaces are ignored. Empty watches all namespaces.")
+	fs.BoolVar(&config.Watch.Namespaced, "namespaced", config.Watch.Namespaced, "Only use namespace-scoped permissions: watch --watch-namespaces, or the --leader-election-namespace if none are given, instead of all namespaces.")
+	fs.BoolVar(&config.InvolvedObjectEvents, "involved-object-events", config.InvolvedObjectEvents, "Also emit trigger events on the object that triggered a template, not only on the EventTriggeredJob.")
+	fs.StringVar(&config.Audit.LogPath, "audit-log-path", config.Audit.LogPath, "File to append a JSON record of every decision taken for a matching trigger to, or - for stdout. Empty disables the audit log.")
+	fs.Var((*int32Value)(&config.Audit.TriggerHistoryLimit), "trigger-history-limit", "Number of trigger records kept in each EventTriggeredJob's status.recentTriggers. 0 keeps none.")
+	fs.BoolVar(&config.Webhooks.Enabled, "enable-webhooks", config.Webhooks.Enabled, "Serve the admission webhooks for EventTriggeredJobs.")
+	fs.StringVar(&config.Webhooks.BindAddress, "webhook-bind-address", config.Webhooks.BindAddress, "The address the admission webhooks bind to.")
+	fs.StringVar(&config.Webhooks.CertDir, "webhook-cert-dir", config.Webhooks.CertDir, "Directory with tls.crt and tls.key of externally managed webhook certificates. If empty, the controller generates its own and stores them in --webhook-secret-name.")
+	fs.StringVar(&config.Webhooks.SecretName, "webhook-secret-name", config.Webhooks.SecretName, "Name of the secret holding generated webhook certificates.")
+	fs.StringVar(&config.Webhooks.ServiceName, "webhook-service-name", config.Webhooks.ServiceName, "Name of the service in front of the webhooks, used for generated certificates.")
+	fs.StringVar(&config.Webhooks.ServiceNamespace, "webhook-service-namespace", config.Webhooks.ServiceNamespace, "Namespace of the webhook service and certificate secret.")
+	fs.StringVar(&config.Webhooks.ConfigName, "webhook-config-name", config.Webhooks.ConfigName, "Name of the validating and mutating webhook configurations to inject the generated CA into. Set to empty to skip the injection.")
+	fs.StringVar(&config.Webhooks.CRDName, "webhook-crd-name", config.Webhooks.CRDName, "Name of the EventTriggeredJob CRD to configure the conversion webhook on and serve v1beta1 from. Set to empty to keep only v1alpha1.")
+	fs.StringVar((*string)(&config.Logging.Format), "log-format", string(config.Logging.Format), "Format of the controller's logs: text, or json for one JSON object per line.")
+	fs.StringVar(&config.Tracing.OTLPEndpoint, "otlp-endpoint", config.Tracing.OTLPEndpoint, "Host and port of an OTLP/HTTP collector to export trigger spans to. Empty disables tracing.")
+	fs.BoolVar(&config.Tracing.OTLPInsecure, "otlp-insecure", config.Tracing.OTLPInsecure, "Export spans to the OTLP collector over plain HTTP instead of HTTPS.")
+	fs.Float64Var(&config.Tracing.SampleRatio, "trace-sample-ratio", config.Tracing.SampleRatio, "Fraction of triggers that are traced, between 0 and 1.")
+	fs.StringVar(&config.MetricsBindAddress, "metrics-bind-address", config.MetricsBindAddress, "The address the /metrics endpoint binds to. Set to 0 to disable it.")
+	fs.StringVar(&config.HealthProbeBindAddress, "health-probe-bind-address", config.HealthProbeBindAddress, "The address the /healthz and /readyz endpoints bind to. Set to 0 to disable them.")
+	fs.BoolVar(&config.LeaderElection.LeaderElect, "leader-elect", config.LeaderElection.LeaderElect, "Elect a leader using a Lease before processing triggers. Required when running more than one replica.")
+	fs.StringVar(&config.LeaderElection.ResourceName, "leader-election-id", config.LeaderElection.ResourceName, "Name of the Lease used for leader election.")
+	fs.StringVar(&config.LeaderElection.ResourceNamespace, "leader-election-namespace", config.LeaderElection.ResourceNamespace, "Namespace of the Lease used for leader election.")
+	fs.DurationVar(&config.LeaderElection.LeaseDuration.Duration, "leader-election-lease-duration", config.LeaderElection.LeaseDuration.Duration, "How long standbys wait before trying to take over a leader's lease.")
+	fs.DurationVar(&config.LeaderElection.RenewDeadline.Duration, "leader-election-renew-deadline", config.LeaderElection.RenewDeadline.Duration, "How long the leader keeps retrying to renew its lease before giving up leadership.")
+	fs.DurationVar(&config.LeaderElection.RetryPeriod.Duration, "leader-election-retry-period", config.LeaderElection.RetryPeriod.Duration, "How long to wait between attempts to acquire or renew the lease.")
+}
+
+// OverrideFromFlags sets the flags registered by AddFlags that were given on the command line in fs
+// on config, so they take precedence over the configuration file
+func OverrideFromFlags(config *KubananaConfiguration, fs *flag.FlagSet) error {
+	overrides := flag.NewFlagSet("", flag.ContinueOnError)
+	AddFlags(overrides, config)
+
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		if err != nil || overrides.Lookup(f.Name) == nil {
+			return
+		}
+		err = overrides.Set(f.Name, f.Value.String())
+	})
+
+	return err
+}
+
+// float32Value is a flag.Value of a float32 field
+type float32Value float32
+
+func (v *float32Value) String() string {
+	return strconv.FormatFloat(float64(*v), 'g', -1, 32)
+}
+
+func (v *float32Value) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return err
+	}
+	*v = float32Value(f)
+	return nil
+}
+
+// int32Value is a flag.Value of an int32 field
+type int32Value int32
+
+func (v *int32Value) String() string {
+	return strconv.FormatInt(int64(*v), 10)
+}
+
+func (v *int32Value) Set(s string) error {
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	*v = int32Value(i)
+	return nil
+}
+
+// triggerSourcesValue is a flag.Value of a comma-separated list of trigger sources
+type triggerSourcesValue []TriggerSource
+
+func (v *triggerSourcesValue) String() string {
+	sources := make([]string, 0, len(*v))
+	for _, source := range *v {
+		sources = append(sources, string(source))
+	}
+	return strings.Join(sources, ",")
+}
+
+func (v *triggerSourcesValue) Set(s string) error {
+	*v = nil
+	for _, source := range strings.Split(s, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			*v = append(*v, TriggerSource(source))
+		}
+	}
+	if len(*v) == 0 {
+		return fmt.Errorf("at least one trigger source is required")
+	}
+	return nil
+}
+
+// namespacesValue is a flag.Value of a comma-separated list of namespaces, ignoring empty entries
+type namespacesValue []string
+
+func (v *namespacesValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *namespacesValue) Set(s string) error {
+	*v = nil
+	for _, namespace := range strings.Split(s, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			*v = append(*v, namespace)
+		}
+	}
+	return nil
+}
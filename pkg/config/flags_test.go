@@ -0,0 +1,115 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAddFlags(t *testing.T) {
+	config := NewDefault()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs, config)
+
+	err := fs.Parse([]string{
+		"--kube-api-qps=50.5",
+		"--kube-api-burst=100",
+		"--trigger-sources=status",
+		"--event-workers=8",
+		"--status-resync-period=10m",
+		"--label-prefix=example.com/",
+		"--watch-namespaces=team-a,,team-b",
+		"--dry-run",
+		"--history-cleanup-interval=5m",
+		"--trigger-history-limit=20",
+		"--enable-webhooks",
+		"--webhook-crd-name=",
+		"--log-format=json",
+		"--otlp-endpoint=collector:4318",
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if config.ClientConnection.QPS != 50.5 || config.ClientConnection.Burst != 100 {
+		t.Errorf("Expected qps 50.5 and burst 100, got %+v", config.ClientConnection)
+	}
+	if !reflect.DeepEqual(config.TriggerSources, []TriggerSource{StatusTriggerSource}) {
+		t.Errorf("Expected only the status trigger source, got %v", config.TriggerSources)
+	}
+	if config.EventController.Workers != 8 || config.StatusController.ResyncPeriod.Duration != 10*time.Minute {
+		t.Errorf("Expected 8 event workers and a 10m status resync, got %+v and %+v", config.EventController, config.StatusController)
+	}
+	if config.Jobs.LabelPrefix != "example.com/" || config.Jobs.AnnotationPrefix != NewDefault().Jobs.AnnotationPrefix {
+		t.Errorf("Expected only the label prefix to change, got %+v", config.Jobs)
+	}
+	if !reflect.DeepEqual(config.Watch.Namespaces, []string{"team-a", "team-b"}) {
+		t.Errorf("Expected namespaces team-a and team-b, got %v", config.Watch.Namespaces)
+	}
+	if !config.Jobs.DryRun || config.Jobs.HistoryCleanupInterval.Duration != 5*time.Minute || config.Audit.TriggerHistoryLimit != 20 {
+		t.Errorf("Expected dry-run, a 5m cleanup interval and 20 trigger records, got %+v and %+v", config.Jobs, config.Audit)
+	}
+	if !config.Webhooks.Enabled || config.Webhooks.CRDName != "" || config.Webhooks.BindAddress != ":9443" {
+		t.Errorf("Expected webhooks on the default address without conversion, got %+v", config.Webhooks)
+	}
+	if config.Logging.Format != JSONLogFormat || config.Tracing.OTLPEndpoint != "collector:4318" || config.Tracing.SampleRatio != 1 {
+		t.Errorf("Expected JSON logs and tracing to collector:4318, got %+v and %+v", config.Logging, config.Tracing)
+	}
+}
+
+func TestAddFlagsRejectsInvalidValues(t *testing.T) {
+	for _, arg := range []string{"--trigger-sources=,", "--event-workers=many", "--kube-api-qps=fast"} {
+		t.Run(arg, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			AddFlags(fs, NewDefault())
+
+			if err := fs.Parse([]string{arg}); err == nil {
+				t.Errorf("Expected %s to be rejected", arg)
+			}
+		})
+	}
+}
+
+func TestOverrideFromFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs, NewDefault())
+	fs.String("config", "", "")
+	if err := fs.Parse([]string{"--config=kubanana.yaml", "--status-workers=6", "--leader-elect", "--watch-namespaces=team-b"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	config, err := Decode([]byte(`
+apiVersion: config.kubanana.roshanbhatia.com/v1alpha1
+kind: KubananaConfiguration
+eventController:
+  workers: 4
+statusController:
+  workers: 3
+watch:
+  namespaces: [team-a]
+  namespaced: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to decode configuration: %v", err)
+	}
+
+	if err := OverrideFromFlags(config, fs); err != nil {
+		t.Fatalf("Failed to override configuration: %v", err)
+	}
+
+	if config.StatusController.Workers != 6 {
+		t.Errorf("Expected --status-workers to take precedence over the file, got %d", config.StatusController.Workers)
+	}
+	if config.EventController.Workers != 4 {
+		t.Errorf("Expected the file's event workers to be kept, got %d", config.EventController.Workers)
+	}
+	if !config.LeaderElection.LeaderElect {
+		t.Error("Expected --leader-elect to enable leader election")
+	}
+	if !reflect.DeepEqual(config.Watch.Namespaces, []string{"team-b"}) || !config.Watch.Namespaced {
+		t.Errorf("Expected --watch-namespaces to replace the file's namespaces, got %+v", config.Watch)
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Load reads the configuration file at path. Fields the file doesn't set keep their defaults.
+func Load(path string) (*KubananaConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	return Decode(data)
+}
+
+// Decode parses a configuration in YAML or JSON over the defaults, rejecting unknown fields and
+// unsupported versions
+func Decode(data []byte) (*KubananaConfiguration, error) {
+	config := NewDefault()
+	config.TypeMeta.APIVersion, config.TypeMeta.Kind = "", ""
+
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.APIVersion != APIVersion || config.Kind != Kind {
+		return nil, fmt.Errorf("unsupported configuration %s of apiVersion %q, expected %s of apiVersion %q",
+			config.Kind, config.APIVersion, Kind, APIVersion)
+	}
+
+	return config, nil
+}
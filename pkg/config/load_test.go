@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadSample(t *testing.T) {
+	config, err := Load(filepath.Join("..", "..", "deploy", "samples", "kubanana-configuration.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load sample configuration: %v", err)
+	}
+
+	if !reflect.DeepEqual(config, NewDefault()) {
+		t.Errorf("Expected the sample to match the defaults, got %+v", config)
+	}
+	if errs := Validate(config); len(errs) > 0 {
+		t.Errorf("Expected the sample to be valid, got %v", errs)
+	}
+}
+
+func TestDecodeKeepsDefaults(t *testing.T) {
+	config, err := Decode([]byte(`
+apiVersion: config.kubanana.roshanbhatia.com/v1alpha1
+kind: KubananaConfiguration
+triggerSources: [status]
+statusController:
+  workers: 4
+leaderElection:
+  leaderElect: true
+  leaseDuration: 30s
+`))
+	if err != nil {
+		t.Fatalf("Failed to decode configuration: %v", err)
+	}
+
+	if !reflect.DeepEqual(config.TriggerSources, []TriggerSource{StatusTriggerSource}) {
+		t.Errorf("Expected only the status trigger source, got %v", config.TriggerSources)
+	}
+	if config.StatusController.Workers != 4 || config.StatusController.ResyncPeriod.Duration != 0 {
+		t.Errorf("Expected 4 status workers without resync, got %+v", config.StatusController)
+	}
+	if config.EventController.Workers != 2 {
+		t.Errorf("Expected the default event workers, got %d", config.EventController.Workers)
+	}
+	election := config.LeaderElection
+	if !election.LeaderElect || election.LeaseDuration.Duration != 30*time.Second || election.RenewDeadline.Duration != 10*time.Second {
+		t.Errorf("Expected the lease duration to be set next to the default renew deadline, got %+v", election)
+	}
+}
+
+func TestDecodeRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "missing kind",
+			data: "apiVersion: config.kubanana.roshanbhatia.com/v1alpha1\n",
+			want: "unsupported configuration",
+		},
+		{
+			name: "unsupported version",
+			data: "apiVersion: config.kubanana.roshanbhatia.com/v1beta1\nkind: KubananaConfiguration\n",
+			want: "unsupported configuration",
+		},
+		{
+			name: "unknown field",
+			data: "apiVersion: config.kubanana.roshanbhatia.com/v1alpha1\nkind: KubananaConfiguration\nworkers: 4\n",
+			want: "unknown field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode([]byte(tt.data)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupName is the API group of the controller's configuration file
+	GroupName = "config.kubanana.roshanbhatia.com"
+
+	// APIVersion is the only supported apiVersion of the configuration file
+	APIVersion = GroupName + "/v1alpha1"
+
+	// Kind is the kind of the configuration file
+	Kind = "KubananaConfiguration"
+)
+
+// TriggerSource is a kind of trigger that runs templates
+type TriggerSource string
+
+const (
+	// EventTriggerSource runs templates with an eventSelector on Kubernetes events
+	EventTriggerSource TriggerSource = "event"
+
+	// StatusTriggerSource runs templates with a statusSelector on status condition changes
+	StatusTriggerSource TriggerSource = "status"
+)
+
+// LogFormat is the format of the controller's logs
+type LogFormat string
+
+const (
+	// TextLogFormat logs klog's text lines
+	TextLogFormat LogFormat = "text"
+
+	// JSONLogFormat logs one JSON object per line
+	JSONLogFormat LogFormat = "json"
+)
+
+// KubananaConfiguration configures the controller. It is read from the file passed with --config,
+// flags set on the command line take precedence over it.
+type KubananaConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClientConnection configures the connection to the API server
+	ClientConnection ClientConnectionConfiguration `json:"clientConnection"`
+
+	// TriggerSources are the kinds of triggers that run templates, the controllers of the others
+	// aren't started
+	TriggerSources []TriggerSource `json:"triggerSources"`
+
+	// EventController configures the controller running templates on events
+	EventController ControllerConfiguration `json:"eventController"`
+
+	// StatusController configures the controller running templates on status condition changes
+	StatusController ControllerConfiguration `json:"statusController"`
+
+	// Watch configures the namespaces the controller watches
+	Watch WatchConfiguration `json:"watch"`
+
+	// Jobs configures the jobs created from templates
+	Jobs JobConfiguration `json:"jobs"`
+
+	// InvolvedObjectEvents also emits trigger events on the object that triggered a template, not
+	// only on the EventTriggeredJob
+	InvolvedObjectEvents bool `json:"involvedObjectEvents"`
+
+	// Audit configures the records kept of the decisions taken for triggers
+	Audit AuditConfiguration `json:"audit"`
+
+	// Webhooks configures the admission and conversion webhooks for EventTriggeredJobs
+	Webhooks WebhookConfiguration `json:"webhooks"`
+
+	// Logging configures the controller's logs
+	Logging LoggingConfiguration `json:"logging"`
+
+	// Tracing configures the export of trigger spans
+	Tracing TracingConfiguration `json:"tracing"`
+
+	// MetricsBindAddress is the address the /metrics endpoint binds to, "0" disables it
+	MetricsBindAddress string `json:"metricsBindAddress"`
+
+	// HealthProbeBindAddress is the address the /healthz and /readyz endpoints bind to, "0"
+	// disables them
+	HealthProbeBindAddress string `json:"healthProbeBindAddress"`
+
+	// LeaderElection configures the election of the replica that processes triggers
+	LeaderElection LeaderElectionConfiguration `json:"leaderElection"`
+}
+
+// ClientConnectionConfiguration configures the connection to the API server
+type ClientConnectionConfiguration struct {
+	// Kubeconfig is the path to a kubeconfig, only required out of cluster
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// QPS is the number of requests per second the controller sends to the API server
+	QPS float32 `json:"qps"`
+
+	// Burst is the number of requests the controller may send above QPS for a short time
+	Burst int32 `json:"burst"`
+}
+
+// ControllerConfiguration configures the event or status controller
+type ControllerConfiguration struct {
+	// Workers is the number of triggers the controller processes concurrently
+	Workers int32 `json:"workers"`
+
+	// ResyncPeriod is how often the controller's informers resync their cache, 0 disables resyncs
+	ResyncPeriod metav1.Duration `json:"resyncPeriod"`
+}
+
+// WatchConfiguration configures the namespaces the controller watches
+type WatchConfiguration struct {
+	// Namespaces restricts the controller to events, resources and EventTriggeredJobs of these
+	// namespaces. Templates in other namespaces are ignored. Empty watches all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Namespaced only uses namespace-scoped permissions: it watches Namespaces, or the leader
+	// election's resourceNamespace if none are given, instead of all namespaces
+	Namespaced bool `json:"namespaced"`
+}
+
+// JobConfiguration configures the jobs created from templates
+type JobConfiguration struct {
+	// DefaultTTLSecondsAfterFinished is applied to created jobs whose template doesn't set one.
+	// A negative value disables the default.
+	DefaultTTLSecondsAfterFinished int32 `json:"defaultTTLSecondsAfterFinished"`
+
+	// DefaultBackoffLimit is applied to created jobs, and set by the defaulting webhook on job
+	// templates, that don't set one. A negative value leaves it to the Job API.
+	DefaultBackoffLimit int32 `json:"defaultBackoffLimit"`
+
+	// RequireServiceAccount only creates jobs for templates that set a serviceAccountName, instead of
+	// creating the others with the controller's permissions
+	RequireServiceAccount bool `json:"requireServiceAccount"`
+
+	// DryRun evaluates triggers and records the jobs they would create, but only creates and deletes
+	// jobs with a server-side dry run
+	DryRun bool `json:"dryRun"`
+
+	// HistoryCleanupInterval is how often finished jobs exceeding a template's history limits are pruned
+	HistoryCleanupInterval metav1.Duration `json:"historyCleanupInterval"`
+
+	// LabelPrefix is the prefix of the labels set on created jobs, such as <prefix>template
+	LabelPrefix string `json:"labelPrefix"`
+
+	// AnnotationPrefix is the prefix of the annotations set on created jobs and their pods, such as
+	// <prefix>trigger-depth
+	AnnotationPrefix string `json:"annotationPrefix"`
+}
+
+// AuditConfiguration configures the records kept of the decisions taken for triggers
+type AuditConfiguration struct {
+	// LogPath is the file to append a JSON record of every decision taken for a matching trigger to,
+	// or - for stdout. Empty disables the audit log.
+	LogPath string `json:"logPath,omitempty"`
+
+	// TriggerHistoryLimit is the number of trigger records kept in each EventTriggeredJob's
+	// status.recentTriggers, 0 keeps none
+	TriggerHistoryLimit int32 `json:"triggerHistoryLimit"`
+}
+
+// WebhookConfiguration configures the admission and conversion webhooks for EventTriggeredJobs
+type WebhookConfiguration struct {
+	// Enabled serves the webhooks
+	Enabled bool `json:"enabled"`
+
+	// BindAddress is the address the webhook server binds to
+	BindAddress string `json:"bindAddress"`
+
+	// CertDir is a directory with tls.crt and tls.key of externally managed certificates. If empty,
+	// the controller generates its own and stores them in SecretName.
+	CertDir string `json:"certDir,omitempty"`
+
+	// SecretName is the name of the secret holding generated certificates
+	SecretName string `json:"secretName"`
+
+	// ServiceName is the name of the service in front of the webhooks, used for generated certificates
+	ServiceName string `json:"serviceName"`
+
+	// ServiceNamespace is the namespace of the webhook service and certificate secret
+	ServiceNamespace string `json:"serviceNamespace"`
+
+	// ConfigName is the name of the validating and mutating webhook configurations to inject the
+	// generated CA into. Empty skips the injection.
+	ConfigName string `json:"configName,omitempty"`
+
+	// CRDName is the name of the EventTriggeredJob CRD to configure the conversion webhook on and serve
+	// v1beta1 from. Empty keeps only v1alpha1.
+	CRDName string `json:"crdName,omitempty"`
+}
+
+// LoggingConfiguration configures the controller's logs
+type LoggingConfiguration struct {
+	// Format is the format of the logs
+	Format LogFormat `json:"format"`
+}
+
+// TracingConfiguration configures the export of trigger spans
+type TracingConfiguration struct {
+	// OTLPEndpoint is the host and port of an OTLP/HTTP collector to export trigger spans to. Empty
+	// disables tracing.
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+
+	// OTLPInsecure exports spans over plain HTTP instead of HTTPS
+	OTLPInsecure bool `json:"otlpInsecure"`
+
+	// SampleRatio is the fraction of triggers that are traced, between 0 and 1
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+// LeaderElectionConfiguration configures the election of the replica that processes triggers
+type LeaderElectionConfiguration struct {
+	// LeaderElect elects a leader using a Lease before processing triggers. Required when running
+	// more than one replica.
+	LeaderElect bool `json:"leaderElect"`
+
+	// ResourceName is the name of the Lease
+	ResourceName string `json:"resourceName"`
+
+	// ResourceNamespace is the namespace of the Lease
+	ResourceNamespace string `json:"resourceNamespace"`
+
+	// LeaseDuration is how long standbys wait before trying to take over a leader's lease
+	LeaseDuration metav1.Duration `json:"leaseDuration"`
+
+	// RenewDeadline is how long the leader keeps retrying to renew its lease before giving up leadership
+	RenewDeadline metav1.Duration `json:"renewDeadline"`
+
+	// RetryPeriod is how long to wait between attempts to acquire or renew the lease
+	RetryPeriod metav1.Duration `json:"retryPeriod"`
+}
+
+// HasTriggerSource checks if triggers of source run templates
+func (c *KubananaConfiguration) HasTriggerSource(source TriggerSource) bool {
+	for _, enabled := range c.TriggerSources {
+		if enabled == source {
+			return true
+		}
+	}
+	return false
+}
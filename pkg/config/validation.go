@@ -0,0 +1,232 @@
+package config
+
+import (
+	"net"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/leaderelection"
+)
+
+// ValidTriggerSources are the trigger sources the controller supports
+var ValidTriggerSources = []TriggerSource{EventTriggerSource, StatusTriggerSource}
+
+// ValidLogFormats are the log formats the controller supports
+var ValidLogFormats = []LogFormat{TextLogFormat, JSONLogFormat}
+
+// longestLabel and longestAnnotation are the longest keys appended to the label and annotation prefixes
+const (
+	longestLabel      = "template-namespace"
+	longestAnnotation = "max-trigger-depth"
+)
+
+// Validate checks that the controller can run with config
+func Validate(config *KubananaConfiguration) field.ErrorList {
+	var allErrs field.ErrorList
+
+	clientPath := field.NewPath("clientConnection")
+	if config.ClientConnection.QPS <= 0 {
+		allErrs = append(allErrs, field.Invalid(clientPath.Child("qps"), config.ClientConnection.QPS, "must be greater than 0"))
+	}
+	if config.ClientConnection.Burst <= 0 {
+		allErrs = append(allErrs, field.Invalid(clientPath.Child("burst"), config.ClientConnection.Burst, "must be greater than 0"))
+	}
+
+	allErrs = append(allErrs, validateTriggerSources(config.TriggerSources, field.NewPath("triggerSources"))...)
+	allErrs = append(allErrs, validateController(config.EventController, field.NewPath("eventController"))...)
+	allErrs = append(allErrs, validateController(config.StatusController, field.NewPath("statusController"))...)
+	allErrs = append(allErrs, validateWatch(config.Watch, field.NewPath("watch"))...)
+	allErrs = append(allErrs, validateJobs(config.Jobs, field.NewPath("jobs"))...)
+	if config.Audit.TriggerHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("audit", "triggerHistoryLimit"),
+			config.Audit.TriggerHistoryLimit, "must not be negative"))
+	}
+	allErrs = append(allErrs, validateWebhooks(config.Webhooks, field.NewPath("webhooks"))...)
+	allErrs = append(allErrs, validateLogging(config.Logging, field.NewPath("logging"))...)
+	allErrs = append(allErrs, validateTracing(config.Tracing, field.NewPath("tracing"))...)
+	allErrs = append(allErrs, validateBindAddress(config.MetricsBindAddress, field.NewPath("metricsBindAddress"))...)
+	allErrs = append(allErrs, validateBindAddress(config.HealthProbeBindAddress, field.NewPath("healthProbeBindAddress"))...)
+	allErrs = append(allErrs, validateLeaderElection(config.LeaderElection, field.NewPath("leaderElection"))...)
+
+	return allErrs
+}
+
+// validateTriggerSources checks that at least one supported trigger source is enabled, each only once
+func validateTriggerSources(sources []TriggerSource, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(sources) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one trigger source is required"))
+	}
+
+	seen := make(map[TriggerSource]bool)
+	for i, source := range sources {
+		if seen[source] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), source))
+			continue
+		}
+		seen[source] = true
+
+		valid := false
+		for _, validSource := range ValidTriggerSources {
+			if source == validSource {
+				valid = true
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), source, ValidTriggerSources))
+		}
+	}
+
+	return allErrs
+}
+
+// validateController checks the settings of the event or status controller
+func validateController(controller ControllerConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if controller.Workers < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("workers"), controller.Workers, "must be at least 1"))
+	}
+	if controller.ResyncPeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("resyncPeriod"), controller.ResyncPeriod.Duration.String(),
+			"must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateWatch checks that the watched namespaces are valid namespace names
+func validateWatch(watch WatchConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, namespace := range watch.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), namespace, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateJobs checks the history cleanup interval and that the prefixes form valid label and
+// annotation keys
+func validateJobs(jobs JobConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if jobs.HistoryCleanupInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("historyCleanupInterval"),
+			jobs.HistoryCleanupInterval.Duration.String(), "must be greater than 0"))
+	}
+
+	if jobs.LabelPrefix == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("labelPrefix"), ""))
+	} else {
+		for _, msg := range validation.IsQualifiedName(jobs.LabelPrefix + longestLabel) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("labelPrefix"), jobs.LabelPrefix, msg))
+		}
+	}
+
+	if jobs.AnnotationPrefix == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("annotationPrefix"), ""))
+	} else {
+		for _, msg := range validation.IsQualifiedName(jobs.AnnotationPrefix + longestAnnotation) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotationPrefix"), jobs.AnnotationPrefix, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateBindAddress checks that address is a host and port, or "0" to disable the endpoint
+func validateBindAddress(address string, fldPath *field.Path) field.ErrorList {
+	if address == "0" {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, address, "must be host:port, or 0 to disable the endpoint")}
+	}
+
+	return nil
+}
+
+// validateWebhooks checks the webhook server's address and the names its certificates are generated
+// for, if the webhooks are enabled
+func validateWebhooks(webhooks WebhookConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !webhooks.Enabled {
+		return allErrs
+	}
+
+	if _, _, err := net.SplitHostPort(webhooks.BindAddress); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bindAddress"), webhooks.BindAddress, "must be host:port"))
+	}
+	if webhooks.ServiceName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceName"), ""))
+	}
+	if webhooks.ServiceNamespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceNamespace"), ""))
+	}
+	if webhooks.CertDir == "" && webhooks.SecretName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("secretName"), "required to store generated certificates"))
+	}
+
+	return allErrs
+}
+
+// validateLogging checks that the log format is supported
+func validateLogging(logging LoggingConfiguration, fldPath *field.Path) field.ErrorList {
+	for _, format := range ValidLogFormats {
+		if logging.Format == format {
+			return nil
+		}
+	}
+
+	return field.ErrorList{field.NotSupported(fldPath.Child("format"), logging.Format, ValidLogFormats)}
+}
+
+// validateTracing checks that the sample ratio is a fraction
+func validateTracing(tracing TracingConfiguration, fldPath *field.Path) field.ErrorList {
+	if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+		return field.ErrorList{field.Invalid(fldPath.Child("sampleRatio"), tracing.SampleRatio, "must be between 0 and 1")}
+	}
+
+	return nil
+}
+
+// validateLeaderElection checks the lease and its timings, which client-go would otherwise reject once
+// the controller is running
+func validateLeaderElection(election LeaderElectionConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !election.LeaderElect {
+		return allErrs
+	}
+
+	if election.ResourceName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceName"), ""))
+	}
+	if election.ResourceNamespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceNamespace"), ""))
+	}
+
+	leaseDuration := election.LeaseDuration.Duration
+	renewDeadline := election.RenewDeadline.Duration
+	retryPeriod := election.RetryPeriod.Duration
+	if retryPeriod <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retryPeriod"), retryPeriod.String(), "must be greater than 0"))
+	}
+	if renewDeadline <= time.Duration(leaderelection.JitterFactor*float64(retryPeriod)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewDeadline"), renewDeadline.String(),
+			"must be greater than retryPeriod times 1.2"))
+	}
+	if leaseDuration <= renewDeadline {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leaseDuration"), leaseDuration.String(),
+			"must be greater than renewDeadline"))
+	}
+
+	return allErrs
+}
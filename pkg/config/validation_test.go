@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(config *KubananaConfiguration)
+		fields []string
+	}{
+		{
+			name:   "defaults",
+			mutate: func(config *KubananaConfiguration) {},
+		},
+		{
+			name: "no qps",
+			mutate: func(config *KubananaConfiguration) {
+				config.ClientConnection.QPS = 0
+			},
+			fields: []string{"clientConnection.qps"},
+		},
+		{
+			name: "no trigger sources",
+			mutate: func(config *KubananaConfiguration) {
+				config.TriggerSources = nil
+			},
+			fields: []string{"triggerSources"},
+		},
+		{
+			name: "unsupported and duplicate trigger sources",
+			mutate: func(config *KubananaConfiguration) {
+				config.TriggerSources = []TriggerSource{EventTriggerSource, "webhook", EventTriggerSource}
+			},
+			fields: []string{"triggerSources[1]", "triggerSources[2]"},
+		},
+		{
+			name: "no workers",
+			mutate: func(config *KubananaConfiguration) {
+				config.StatusController.Workers = 0
+			},
+			fields: []string{"statusController.workers"},
+		},
+		{
+			name: "negative resync period",
+			mutate: func(config *KubananaConfiguration) {
+				config.EventController.ResyncPeriod = metav1.Duration{Duration: -time.Minute}
+			},
+			fields: []string{"eventController.resyncPeriod"},
+		},
+		{
+			name: "custom prefixes",
+			mutate: func(config *KubananaConfiguration) {
+				config.Jobs.LabelPrefix = "example.com/"
+				config.Jobs.AnnotationPrefix = "example.com/kubanana-"
+			},
+		},
+		{
+			name: "invalid prefixes",
+			mutate: func(config *KubananaConfiguration) {
+				config.Jobs.LabelPrefix = "_kubanana-"
+				config.Jobs.AnnotationPrefix = ""
+			},
+			fields: []string{"jobs.labelPrefix", "jobs.annotationPrefix"},
+		},
+		{
+			name: "disabled endpoints",
+			mutate: func(config *KubananaConfiguration) {
+				config.MetricsBindAddress = "0"
+				config.HealthProbeBindAddress = "0"
+			},
+		},
+		{
+			name: "invalid bind address",
+			mutate: func(config *KubananaConfiguration) {
+				config.MetricsBindAddress = "8080"
+			},
+			fields: []string{"metricsBindAddress"},
+		},
+		{
+			name: "invalid watch namespace",
+			mutate: func(config *KubananaConfiguration) {
+				config.Watch.Namespaces = []string{"team-a", "Team_B"}
+			},
+			fields: []string{"watch.namespaces[1]"},
+		},
+		{
+			name: "no history cleanup interval",
+			mutate: func(config *KubananaConfiguration) {
+				config.Jobs.HistoryCleanupInterval = metav1.Duration{}
+			},
+			fields: []string{"jobs.historyCleanupInterval"},
+		},
+		{
+			name: "negative trigger history limit",
+			mutate: func(config *KubananaConfiguration) {
+				config.Audit.TriggerHistoryLimit = -1
+			},
+			fields: []string{"audit.triggerHistoryLimit"},
+		},
+		{
+			name: "webhooks without a service",
+			mutate: func(config *KubananaConfiguration) {
+				config.Webhooks.Enabled = true
+				config.Webhooks.BindAddress = "9443"
+				config.Webhooks.ServiceName = ""
+			},
+			fields: []string{"webhooks.bindAddress", "webhooks.serviceName"},
+		},
+		{
+			name: "webhooks with external certificates",
+			mutate: func(config *KubananaConfiguration) {
+				config.Webhooks.Enabled = true
+				config.Webhooks.CertDir = "/etc/kubanana/webhook-certs"
+				config.Webhooks.SecretName = ""
+			},
+		},
+		{
+			name: "webhooks disabled",
+			mutate: func(config *KubananaConfiguration) {
+				config.Webhooks.BindAddress = ""
+				config.Webhooks.ServiceName = ""
+			},
+		},
+		{
+			name: "unsupported log format",
+			mutate: func(config *KubananaConfiguration) {
+				config.Logging.Format = "logfmt"
+			},
+			fields: []string{"logging.format"},
+		},
+		{
+			name: "sample ratio above 1",
+			mutate: func(config *KubananaConfiguration) {
+				config.Tracing.SampleRatio = 2
+			},
+			fields: []string{"tracing.sampleRatio"},
+		},
+		{
+			name: "lease shorter than renew deadline",
+			mutate: func(config *KubananaConfiguration) {
+				config.LeaderElection.LeaderElect = true
+				config.LeaderElection.LeaseDuration = metav1.Duration{Duration: 5 * time.Second}
+			},
+			fields: []string{"leaderElection.leaseDuration"},
+		},
+		{
+			name: "leader election without a lease",
+			mutate: func(config *KubananaConfiguration) {
+				config.LeaderElection.LeaderElect = true
+				config.LeaderElection.ResourceName = ""
+				config.LeaderElection.RetryPeriod = metav1.Duration{Duration: 9 * time.Second}
+			},
+			fields: []string{"leaderElection.resourceName", "leaderElection.renewDeadline"},
+		},
+		{
+			name: "leader election disabled",
+			mutate: func(config *KubananaConfiguration) {
+				config.LeaderElection.ResourceName = ""
+				config.LeaderElection.LeaseDuration = metav1.Duration{}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewDefault()
+			tt.mutate(config)
+
+			errs := Validate(config)
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs[i].Field != field {
+					t.Errorf("Expected error on %s, got %s", field, errs[i].Field)
+				}
+			}
+		})
+	}
+}
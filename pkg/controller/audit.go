@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/audit"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// newTriggerRecord describes the outcome of a trigger about the involved object that matched a template
+func newTriggerRecord(
+	trigger, eventType string,
+	involved *corev1.ObjectReference,
+	match MatchResult,
+	outcome v1alpha1.TriggerOutcome,
+	job, reason string) v1alpha1.TriggerRecord {
+
+	record := v1alpha1.TriggerRecord{
+		Time:      metav1.Now(),
+		Trigger:   trigger,
+		EventType: eventType,
+		Resource:  *involved,
+		Outcome:   outcome,
+		Job:       job,
+		Reason:    reason,
+	}
+	for _, field := range match.Fields {
+		record.Match = append(record.Match, field.Field+": "+field.Reason)
+	}
+
+	return record
+}
+
+// triggerHistoryFlushDelay is how long trigger records of a template are collected before they're
+// written to its status in a single update
+const triggerHistoryFlushDelay = 2 * time.Second
+
+// writeTriggerRecord writes the record to the audit log and, if the controller keeps a trigger
+// history, queues it for the template's status
+func writeTriggerRecord(
+	ctx context.Context,
+	history *triggerHistory,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	record v1alpha1.TriggerRecord) {
+
+	if options.AuditLog != nil {
+		err := options.AuditLog.Write(audit.Record{
+			TemplateNamespace: template.Namespace,
+			Template:          template.Name,
+			TemplateUID:       template.UID,
+			TraceID:           traceIDFromContext(ctx),
+			TriggerRecord:     record,
+		})
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "Failed to write audit record")
+		}
+	}
+
+	history.add(template, record)
+}
+
+// triggerHistory coalesces the trigger records of each template, so a burst of triggers results in
+// one status update instead of one per trigger
+type triggerHistory struct {
+	kubananaClient versioned.Interface
+	limit          int
+	delay          time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*pendingTriggerRecords
+}
+
+// pendingTriggerRecords are the records of a template waiting to be written, oldest first
+type pendingTriggerRecords struct {
+	template *v1alpha1.EventTriggeredJob
+	records  []v1alpha1.TriggerRecord
+}
+
+// newTriggerHistory creates a triggerHistory keeping limit records per template, none if limit is 0
+func newTriggerHistory(kubananaClient versioned.Interface, limit int) *triggerHistory {
+	return &triggerHistory{
+		kubananaClient: kubananaClient,
+		limit:          limit,
+		delay:          triggerHistoryFlushDelay,
+		pending:        make(map[string]*pendingTriggerRecords),
+	}
+}
+
+// add queues the record for the template's status, scheduling a write if none is pending
+func (h *triggerHistory) add(template *v1alpha1.EventTriggeredJob, record v1alpha1.TriggerRecord) {
+	if h.limit <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := template.Namespace + "/" + template.Name
+	if pending, exists := h.pending[key]; exists {
+		// Only the newest records end up in the status
+		pending.records = append(pending.records, record)
+		if len(pending.records) > h.limit {
+			pending.records = pending.records[len(pending.records)-h.limit:]
+		}
+		return
+	}
+
+	h.pending[key] = &pendingTriggerRecords{template: template, records: []v1alpha1.TriggerRecord{record}}
+	time.AfterFunc(h.delay, func() {
+		h.flush(key)
+	})
+}
+
+// flush writes the pending records of the template with key to its status
+func (h *triggerHistory) flush(key string) {
+	h.mu.Lock()
+	pending, exists := h.pending[key]
+	delete(h.pending, key)
+	h.mu.Unlock()
+
+	if !exists {
+		return
+	}
+
+	err := updateTemplateStatus(context.Background(), h.kubananaClient, pending.template, func(status *v1alpha1.EventTriggeredJobStatus) {
+		for _, record := range pending.records {
+			status.RecentTriggers = prependTriggerRecord(status.RecentTriggers, record, h.limit)
+		}
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to write trigger history", logKeyTemplate, key)
+	}
+}
+
+// flushAll writes the pending records of all templates, e.g. before the controller shuts down
+func (h *triggerHistory) flushAll() {
+	h.mu.Lock()
+	keys := make([]string, 0, len(h.pending))
+	for key := range h.pending {
+		keys = append(keys, key)
+	}
+	h.mu.Unlock()
+
+	for _, key := range keys {
+		h.flush(key)
+	}
+}
+
+// prependTriggerRecord adds record as the newest of records, keeping at most limit of them
+func prependTriggerRecord(records []v1alpha1.TriggerRecord, record v1alpha1.TriggerRecord, limit int) []v1alpha1.TriggerRecord {
+	records = append([]v1alpha1.TriggerRecord{record}, records...)
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	return records
+}
+
+// suspendedOutcome is the outcome of a trigger of a suspended template
+func suspendedOutcome(template *v1alpha1.EventTriggeredJob) v1alpha1.TriggerOutcome {
+	if template.Spec.SuspendPolicy == v1alpha1.QueueLatestSuspendPolicy {
+		return v1alpha1.QueuedTriggerOutcome
+	}
+
+	return v1alpha1.DroppedTriggerOutcome
+}
+
+// jobRef returns the namespace and name of a created job
+func jobRef(job *batchv1.Job) string {
+	return job.Namespace + "/" + job.Name
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/audit"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestPrependTriggerRecord(t *testing.T) {
+	var records []v1alpha1.TriggerRecord
+	for _, reason := range []string{"first", "second", "third"} {
+		records = prependTriggerRecord(records, v1alpha1.TriggerRecord{Reason: reason}, 2)
+	}
+
+	if len(records) != 2 || records[0].Reason != "third" || records[1].Reason != "second" {
+		t.Errorf("Expected the two newest records, newest first, got %+v", records)
+	}
+}
+
+func TestEventControllerAuditsTriggers(t *testing.T) {
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{MaxJobs: 1, Interval: &metav1.Duration{Duration: time.Hour}})
+	template.Spec.EventSelector = &v1alpha1.EventSelector{ResourceKind: "Pod", NamePattern: "web-*", EventTypes: []string{"CREATE"}}
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+
+	var buf bytes.Buffer
+	c := NewEventControllerWithOptions(fake.NewSimpleClientset(), kubananaClient, Options{
+		AuditLog:            audit.NewLog(&buf),
+		TriggerHistoryLimit: 5,
+	})
+	c.recorder = record.NewFakeRecorder(10)
+
+	event := &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1", UID: "pod-uid", ResourceVersion: "42"},
+		Reason:         "Created",
+	}
+	ctx := withTrigger(context.Background(), template, "event", triggerResource("Pod", "default", "web-1"))
+	c.triggerJob(ctx, template, event, "CREATE", triggerOrigin{}, nil)
+	c.triggerJob(ctx, template, event, "CREATE", triggerOrigin{}, nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected an audit record per trigger, got %q", buf.String())
+	}
+	var created audit.Record
+	if err := json.Unmarshal([]byte(lines[0]), &created); err != nil {
+		t.Fatalf("Failed to decode audit record: %v", err)
+	}
+	if created.Template != "test-template" || created.Outcome != v1alpha1.JobCreatedTriggerOutcome ||
+		!strings.HasPrefix(created.Job, "default/") || created.Resource.ResourceVersion != "42" {
+		t.Errorf("Expected the created job with the triggering resource, got %+v", created)
+	}
+	if created.TraceID != traceIDFromContext(ctx) {
+		t.Errorf("Expected the trace ID of the trigger, got %q", created.TraceID)
+	}
+	if len(created.Match) == 0 || !strings.Contains(strings.Join(created.Match, "\n"), "spec.eventSelector.namePattern") {
+		t.Errorf("Expected the matched selector fields, got %v", created.Match)
+	}
+
+	// The records are written to the status together once the flush delay passed
+	c.history.flushAll()
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if updated.Status.JobsCreated != 1 || updated.Status.LastTriggeredTime == nil {
+		t.Errorf("Expected the created job to be counted with its trigger time, got %+v", updated.Status)
+	}
+	recent := updated.Status.RecentTriggers
+	if len(recent) != 2 || recent[0].Outcome != v1alpha1.DroppedTriggerOutcome || recent[0].Reason != dropReasonRateLimited {
+		t.Errorf("Expected the rate-limited trigger to be the newest record, got %+v", recent)
+	}
+}
+
+func TestTriggerHistoryCoalescesRecords(t *testing.T) {
+	template := newRateLimitedTemplate(nil)
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+	history := newTriggerHistory(kubananaClient, 2)
+	history.delay = time.Hour
+
+	for _, reason := range []string{"first", "second", "third"} {
+		history.add(template, v1alpha1.TriggerRecord{Outcome: v1alpha1.DebouncedTriggerOutcome, Reason: reason})
+	}
+	if actions := kubananaClient.Actions(); len(actions) != 0 {
+		t.Fatalf("Expected no status update before the flush, got %v", actions)
+	}
+
+	history.flushAll()
+
+	updates := 0
+	for _, action := range kubananaClient.Actions() {
+		if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+			updates++
+		}
+	}
+	if updates != 1 {
+		t.Errorf("Expected the burst to be written in one status update, got %d", updates)
+	}
+
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	recent := updated.Status.RecentTriggers
+	if len(recent) != 2 || recent[0].Reason != "third" || recent[1].Reason != "second" {
+		t.Errorf("Expected the two newest records, newest first, got %+v", recent)
+	}
+}
+
+func TestTriggerHistoryDisabled(t *testing.T) {
+	kubananaClient := kubananafake.NewSimpleClientset()
+	history := newTriggerHistory(kubananaClient, 0)
+
+	history.add(newRateLimitedTemplate(nil), v1alpha1.TriggerRecord{Reason: "ignored"})
+	history.flushAll()
+
+	if actions := kubananaClient.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no status updates without a trigger history, got %v", actions)
+	}
+}
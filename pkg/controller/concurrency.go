@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// dropReasonConcurrencyForbidden is recorded when a trigger is skipped by the Forbid policy
+	dropReasonConcurrencyForbidden = "ConcurrencyForbidden"
+)
+
+// applyConcurrencyPolicy enforces the template's ConcurrencyPolicy before a new job is created
+// in the given namespace for the triggering resource. It returns false if the new trigger should
+// be skipped. Jobs replaced in a dry run are only deleted server-side.
+func applyConcurrencyPolicy(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	namespace string,
+	resource corev1.ObjectReference) (bool, error) {
+
+	policy := template.Spec.ConcurrencyPolicy
+	if policy == "" || policy == v1alpha1.AllowConcurrent {
+		return true, nil
+	}
+
+	activeJobs, err := listActiveJobs(ctx, kubeClient, options, template, namespace, resource)
+	if err != nil {
+		return false, err
+	}
+
+	if len(activeJobs) == 0 {
+		return true, nil
+	}
+
+	switch policy {
+	case v1alpha1.ForbidConcurrent:
+		klog.FromContext(ctx).Info("Skipping trigger, jobs are still active and concurrency policy is Forbid",
+			"activeJobs", len(activeJobs))
+		return false, nil
+	case v1alpha1.ReplaceConcurrent:
+		propagation := metav1.DeletePropagationBackground
+		dryRun := isDryRun(options, template)
+		for _, job := range activeJobs {
+			err := kubeClient.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+				PropagationPolicy: &propagation,
+				DryRun:            dryRunOption(dryRun),
+			})
+			if err != nil {
+				return false, fmt.Errorf("failed to delete active job %s/%s: %w", job.Namespace, job.Name, err)
+			}
+			if dryRun {
+				klog.FromContext(ctx).Info("Dry run: would have deleted active job to replace it", logKeyJob, klog.KObj(&job))
+				continue
+			}
+			klog.FromContext(ctx).Info("Deleted active job to replace it", logKeyJob, klog.KObj(&job))
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown concurrency policy %q", policy)
+	}
+}
+
+// listActiveJobs returns the unfinished jobs created by the template, scoped by its ConcurrencyScope.
+// Resources are told apart by kind, namespace and name, since jobs may all run in the template's namespace.
+func listActiveJobs(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	namespace string,
+	resource corev1.ObjectReference) ([]batchv1.Job, error) {
+
+	selector := labels.Set{options.labelKey(TemplateLabel): template.Name}
+	if template.Spec.ConcurrencyScope == v1alpha1.ResourceConcurrencyScope {
+		selector[options.labelKey(ResourceKindLabel)] = resource.Kind
+		selector[options.labelKey(ResourceNamespaceLabel)] = resource.Namespace
+		selector[options.labelKey(ResourceNameLabel)] = resource.Name
+	}
+
+	jobList, err := kubeClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs for template %s: %w", template.Name, err)
+	}
+
+	active := []batchv1.Job{}
+	for _, job := range jobList.Items {
+		if !isJobOwnedBy(&job, template, options) || isJobFinished(&job) {
+			continue
+		}
+		active = append(active, job)
+	}
+
+	return active, nil
+}
+
+// isJobOwnedBy checks if the job is controlled by the given template
+func isJobOwnedBy(job *batchv1.Job, template *v1alpha1.EventTriggeredJob, options Options) bool {
+	// Jobs created before the template had a UID assigned (e.g. in tests) are matched by label only
+	if template.UID == "" {
+		return true
+	}
+
+	for _, ref := range job.OwnerReferences {
+		if ref.UID == template.UID {
+			return true
+		}
+	}
+
+	// Jobs in another namespace can't reference the template and are matched by labels
+	return job.Namespace != template.Namespace &&
+		job.Labels[options.labelKey(TemplateLabel)] == template.Name &&
+		job.Labels[options.labelKey(TemplateNamespaceLabel)] == template.Namespace
+}
+
+// isJobFinished checks if the job has completed or failed
+func isJobFinished(job *batchv1.Job) bool {
+	return isJobConditionTrue(job, batchv1.JobComplete) || isJobConditionTrue(job, batchv1.JobFailed)
+}
+
+// isJobConditionTrue checks if the job has the given condition set to true
+func isJobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == conditionType && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
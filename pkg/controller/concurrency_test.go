@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTestJob creates a job as if it was created by the given template for the given resource
+func newTestJob(name, templateName, resourceName string, finished bool) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				TemplateLabel:          templateName,
+				ResourceKindLabel:      "Pod",
+				ResourceNamespaceLabel: "default",
+				ResourceNameLabel:      resourceName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "kubanana.roshanbhatia.com/v1alpha1",
+					Kind:       "EventTriggeredJob",
+					Name:       templateName,
+					UID:        types.UID(templateName + "-uid"),
+				},
+			},
+		},
+	}
+
+	if finished {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			},
+		}
+	}
+
+	return job
+}
+
+// withTriggeringResource changes the resource the test job was created for
+func withTriggeringResource(job *batchv1.Job, kind, namespace string) *batchv1.Job {
+	job.Labels[ResourceKindLabel] = kind
+	job.Labels[ResourceNamespaceLabel] = namespace
+	return job
+}
+
+func TestApplyConcurrencyPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          v1alpha1.ConcurrencyPolicy
+		scope           v1alpha1.ConcurrencyScope
+		existingJobs    []runtime.Object
+		resourceName    string
+		shouldProceed   bool
+		expectedJobsLen int
+	}{
+		{
+			name:            "allow with active job",
+			policy:          v1alpha1.AllowConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "empty policy defaults to allow",
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "forbid with active job",
+			policy:          v1alpha1.ForbidConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   false,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "forbid with finished job",
+			policy:          v1alpha1.ForbidConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", true)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "forbid ignores other templates",
+			policy:          v1alpha1.ForbidConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "other-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "forbid with resource scope and other resource",
+			policy:          v1alpha1.ForbidConcurrent,
+			scope:           v1alpha1.ResourceConcurrencyScope,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-b", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:   "forbid with resource scope and other kind",
+			policy: v1alpha1.ForbidConcurrent,
+			scope:  v1alpha1.ResourceConcurrencyScope,
+			existingJobs: []runtime.Object{
+				withTriggeringResource(newTestJob("job-1", "test-template", "pod-a", false), "Deployment", "default"),
+			},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:   "replace with resource scope keeps job of other namespace",
+			policy: v1alpha1.ReplaceConcurrent,
+			scope:  v1alpha1.ResourceConcurrencyScope,
+			existingJobs: []runtime.Object{
+				withTriggeringResource(newTestJob("job-1", "test-template", "pod-a", false), "Pod", "other"),
+			},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "replace with resource scope deletes job of same resource",
+			policy:          v1alpha1.ReplaceConcurrent,
+			scope:           v1alpha1.ResourceConcurrencyScope,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 0,
+		},
+		{
+			name:            "forbid with template scope and other resource",
+			policy:          v1alpha1.ForbidConcurrent,
+			scope:           v1alpha1.TemplateConcurrencyScope,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-b", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   false,
+			expectedJobsLen: 1,
+		},
+		{
+			name:            "replace deletes active job",
+			policy:          v1alpha1.ReplaceConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", false)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 0,
+		},
+		{
+			name:            "replace keeps finished job",
+			policy:          v1alpha1.ReplaceConcurrent,
+			existingJobs:    []runtime.Object{newTestJob("job-1", "test-template", "pod-a", true)},
+			resourceName:    "pod-a",
+			shouldProceed:   true,
+			expectedJobsLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset(tt.existingJobs...)
+
+			template := &v1alpha1.EventTriggeredJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "default",
+					UID:       types.UID("test-template-uid"),
+				},
+				Spec: v1alpha1.EventTriggeredJobSpec{
+					ConcurrencyPolicy: tt.policy,
+					ConcurrencyScope:  tt.scope,
+				},
+			}
+
+			resource := corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: tt.resourceName}
+			proceed, err := applyConcurrencyPolicy(context.Background(), kubeClient, Options{}, template, "default", resource)
+			if err != nil {
+				t.Fatalf("applyConcurrencyPolicy() returned error: %v", err)
+			}
+
+			if proceed != tt.shouldProceed {
+				t.Errorf("applyConcurrencyPolicy() = %v, want %v", proceed, tt.shouldProceed)
+			}
+
+			jobs, err := kubeClient.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list jobs: %v", err)
+			}
+
+			if len(jobs.Items) != tt.expectedJobsLen {
+				t.Errorf("Expected %d jobs, got %d", tt.expectedJobsLen, len(jobs.Items))
+			}
+		})
+	}
+}
+
+func TestIsJobFinished(t *testing.T) {
+	if isJobFinished(newTestJob("job-1", "test-template", "pod-a", false)) {
+		t.Errorf("Expected job without conditions to be active")
+	}
+
+	if !isJobFinished(newTestJob("job-1", "test-template", "pod-a", true)) {
+		t.Errorf("Expected completed job to be finished")
+	}
+
+	failedJob := newTestJob("job-1", "test-template", "pod-a", false)
+	failedJob.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:   batchv1.JobFailed,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	if !isJobFinished(failedJob) {
+		t.Errorf("Expected failed job to be finished")
+	}
+}
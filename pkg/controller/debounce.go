@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// dropReasonDebounced is reported in metrics for triggers coalesced into another trigger's job
+	dropReasonDebounced = "Debounced"
+
+	// dropReasonShutdown is reported in metrics for debounced triggers dropped on shutdown
+	dropReasonShutdown = "Shutdown"
+)
+
+// debouncedTrigger describes a single trigger that was coalesced into a batch
+type debouncedTrigger struct {
+	ResourceKind      string      `json:"resourceKind"`
+	ResourceName      string      `json:"resourceName"`
+	ResourceNamespace string      `json:"resourceNamespace"`
+	TriggerType       string      `json:"triggerType"`
+	Time              metav1.Time `json:"time"`
+}
+
+// triggerBatch holds the triggers collected for a debounce key
+type triggerBatch struct {
+	triggers []debouncedTrigger
+	flush    func(triggers []debouncedTrigger)
+}
+
+// debouncer collects bursts of triggers and flushes each batch once its window has passed.
+// Once stopped it doesn't flush any more batches, so a replica that shut down or lost its
+// leadership doesn't create jobs next to the new leader.
+type debouncer struct {
+	mu        sync.Mutex
+	batches   map[string]*triggerBatch
+	stopped   bool
+	trigger   string
+	afterFunc func(d time.Duration, f func())
+}
+
+// newDebouncer creates a new debouncer for triggers of the given type
+func newDebouncer(trigger string) *debouncer {
+	return &debouncer{
+		batches: make(map[string]*triggerBatch),
+		trigger: trigger,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// add collects the trigger into the batch for key. The first trigger of a batch starts the
+// window; once it passes, the most recently supplied flush is called with all collected triggers.
+func (d *debouncer) add(key string, window time.Duration, trigger debouncedTrigger, flush func(triggers []debouncedTrigger)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		klog.InfoS("Dropped debounced trigger on shutdown", "batch", key,
+			logKeyResource, triggerResource(trigger.ResourceKind, trigger.ResourceNamespace, trigger.ResourceName))
+		d.countDropped(key, 1)
+		return
+	}
+
+	if batch, exists := d.batches[key]; exists {
+		batch.triggers = append(batch.triggers, trigger)
+		batch.flush = flush
+		klog.V(4).InfoS("Debounced trigger into batch", "batch", key,
+			logKeyResource, triggerResource(trigger.ResourceKind, trigger.ResourceNamespace, trigger.ResourceName),
+			"triggers", len(batch.triggers))
+		return
+	}
+
+	d.batches[key] = &triggerBatch{
+		triggers: []debouncedTrigger{trigger},
+		flush:    flush,
+	}
+	klog.V(4).InfoS("Started debounce batch", "batch", key, "window", window)
+
+	d.afterFunc(window, func() {
+		d.mu.Lock()
+		batch := d.batches[key]
+		delete(d.batches, key)
+		stopped := d.stopped
+		d.mu.Unlock()
+
+		if batch != nil && !stopped {
+			batch.flush(batch.triggers)
+		}
+	})
+}
+
+// stop drops the pending batches and keeps any later ones from being flushed. Their triggers are
+// left to the replica that takes over.
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopped = true
+	for key, batch := range d.batches {
+		klog.InfoS("Dropped debounced batch on shutdown", "batch", key, "triggers", len(batch.triggers))
+		d.countDropped(key, len(batch.triggers))
+	}
+	d.batches = make(map[string]*triggerBatch)
+}
+
+// countDropped counts triggers of the batch with key that were dropped on shutdown against its template
+func (d *debouncer) countDropped(key string, count int) {
+	// Batch keys start with the template's namespace and name
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 2 {
+		return
+	}
+	metrics.TriggerSkipped(parts[0], parts[1], d.trigger, dropReasonShutdown, count)
+}
+
+// debounceKey returns the key a trigger is batched under for the template's debounce settings
+func debounceKey(template *v1alpha1.EventTriggeredJob, resourceKey, ownerKey string) string {
+	key := template.Namespace + "/" + template.Name
+
+	switch template.Spec.Debounce.Key {
+	case v1alpha1.ResourceDebounceKey:
+		return key + "/" + resourceKey
+	case v1alpha1.OwnerDebounceKey:
+		return key + "/" + ownerKey
+	default:
+		return key
+	}
+}
+
+// resolveOwnerKey returns a key for the top-level controller of a resource. Pods owned by a
+// ReplicaSet are resolved to the ReplicaSet's Deployment so a whole rollout shares one key.
+// If the resource has no controller, fallback is returned.
+func resolveOwnerKey(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespace string,
+	ownerRefs []metav1.OwnerReference,
+	fallback string) string {
+
+	owner := metav1.GetControllerOfNoCopy(&metav1.ObjectMeta{OwnerReferences: ownerRefs})
+	if owner == nil {
+		return fallback
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		replicaSet, err := kubeClient.AppsV1().ReplicaSets(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.FromContext(ctx).V(4).Info("Failed to get ReplicaSet to resolve its owner",
+				"replicaSet", klog.KRef(namespace, owner.Name), "err", err)
+		} else if deployment := metav1.GetControllerOf(replicaSet); deployment != nil {
+			owner = deployment
+		}
+	}
+
+	return owner.Kind + "/" + namespace + "/" + owner.Name
+}
+
+// lookupOwnerReferences fetches the owner references of a resource referenced by an event.
+// Only kinds the typed client knows about are supported; other kinds return nil.
+func lookupOwnerReferences(ctx context.Context, kubeClient kubernetes.Interface, ref corev1.ObjectReference) []metav1.OwnerReference {
+	objMeta, err := lookupObjectMeta(ctx, kubeClient, ref.Kind, ref.Namespace, ref.Name)
+	if err != nil {
+		klog.FromContext(ctx).V(4).Info("Failed to get resource to resolve its owner", "err", err)
+		return nil
+	}
+	if objMeta == nil {
+		return nil
+	}
+
+	return objMeta.GetOwnerReferences()
+}
+
+// lookupObjectMeta fetches the metadata of a resource. Only kinds the typed client knows about
+// are supported; other kinds return nil.
+func lookupObjectMeta(ctx context.Context, kubeClient kubernetes.Interface, kind, namespace, name string) (metav1.Object, error) {
+	switch kind {
+	case "Pod":
+		return kubeClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "ReplicaSet":
+		return kubeClient.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "Job":
+		return kubeClient.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+}
+
+// addTriggerBatchEnv exposes the coalesced triggers of a batch to every container of the job
+func addTriggerBatchEnv(job *batchv1.Job, triggers []debouncedTrigger) {
+	if len(triggers) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(triggers)
+	if err != nil {
+		klog.ErrorS(err, "Failed to encode debounced triggers", logKeyJob, klog.KObj(job))
+		return
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "TRIGGER_COUNT", Value: strconv.Itoa(len(triggers))},
+		{Name: "TRIGGERS", Value: string(payload)},
+	}
+
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, envVars...)
+	}
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDebouncerCoalescesTriggers(t *testing.T) {
+	d := newDebouncer(eventTrigger)
+
+	// Capture the timer instead of waiting for it
+	var fire func()
+	var window time.Duration
+	timers := 0
+	d.afterFunc = func(w time.Duration, f func()) {
+		timers++
+		window = w
+		fire = f
+	}
+
+	var flushed []debouncedTrigger
+	flushCalls := 0
+	flush := func(triggers []debouncedTrigger) {
+		flushCalls++
+		flushed = triggers
+	}
+
+	for _, name := range []string{"pod-a", "pod-b", "pod-c"} {
+		d.add("default/test-template", 10*time.Second, debouncedTrigger{ResourceKind: "Pod", ResourceName: name}, flush)
+	}
+
+	if timers != 1 {
+		t.Fatalf("Expected 1 debounce timer, got %d", timers)
+	}
+	if window != 10*time.Second {
+		t.Errorf("Expected window of 10s, got %s", window)
+	}
+
+	fire()
+
+	if flushCalls != 1 {
+		t.Fatalf("Expected flush to be called once, got %d", flushCalls)
+	}
+	if len(flushed) != 3 {
+		t.Fatalf("Expected 3 triggers in the batch, got %d", len(flushed))
+	}
+	if flushed[2].ResourceName != "pod-c" {
+		t.Errorf("Expected triggers in arrival order, got %s last", flushed[2].ResourceName)
+	}
+
+	// A trigger after the flush starts a new batch
+	d.add("default/test-template", 10*time.Second, debouncedTrigger{ResourceKind: "Pod", ResourceName: "pod-d"}, flush)
+	if timers != 2 {
+		t.Errorf("Expected a new debounce timer after the flush, got %d timers", timers)
+	}
+}
+
+func TestDebouncerStop(t *testing.T) {
+	d := newDebouncer(statusTrigger)
+
+	var fire func()
+	timers := 0
+	d.afterFunc = func(w time.Duration, f func()) {
+		timers++
+		fire = f
+	}
+
+	flushCalls := 0
+	flush := func(triggers []debouncedTrigger) {
+		flushCalls++
+	}
+
+	d.add("default/test-template", 10*time.Second, debouncedTrigger{ResourceKind: "Pod", ResourceName: "pod-a"}, flush)
+	d.stop()
+
+	// The window of the pending batch ends after the controller stopped
+	fire()
+	if flushCalls != 0 {
+		t.Errorf("Expected the pending batch not to be flushed after stopping, got %d flushes", flushCalls)
+	}
+
+	// Later triggers don't start a new batch
+	d.add("default/test-template", 10*time.Second, debouncedTrigger{ResourceKind: "Pod", ResourceName: "pod-b"}, flush)
+	if timers != 1 || len(d.batches) != 0 {
+		t.Errorf("Expected no batch after stopping, got %d timers and %d batches", timers, len(d.batches))
+	}
+}
+
+func TestDebounceKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      v1alpha1.DebounceKey
+		expected string
+	}{
+		{
+			name:     "default to template",
+			expected: "default/test-template",
+		},
+		{
+			name:     "template",
+			key:      v1alpha1.TemplateDebounceKey,
+			expected: "default/test-template",
+		},
+		{
+			name:     "resource",
+			key:      v1alpha1.ResourceDebounceKey,
+			expected: "default/test-template/Pod/default/web-abc-123",
+		},
+		{
+			name:     "owner",
+			key:      v1alpha1.OwnerDebounceKey,
+			expected: "default/test-template/Deployment/default/web",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &v1alpha1.EventTriggeredJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.EventTriggeredJobSpec{
+					Debounce: &v1alpha1.Debounce{
+						Window: metav1.Duration{Duration: time.Second},
+						Key:    tt.key,
+					},
+				},
+			}
+
+			key := debounceKey(template, "Pod/default/web-abc-123", "Deployment/default/web")
+			if key != tt.expected {
+				t.Errorf("debounceKey() = %s, want %s", key, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveOwnerKey(t *testing.T) {
+	isController := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: &isController},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset(replicaSet)
+
+	podOwners := []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", Controller: &isController},
+	}
+	if key := resolveOwnerKey(context.Background(), kubeClient, "default", podOwners, "fallback"); key != "Deployment/default/web" {
+		t.Errorf("Expected pod to resolve to its Deployment, got %s", key)
+	}
+
+	jobOwners := []metav1.OwnerReference{
+		{APIVersion: "batch/v1", Kind: "Job", Name: "migrate", Controller: &isController},
+	}
+	if key := resolveOwnerKey(context.Background(), kubeClient, "default", jobOwners, "fallback"); key != "Job/default/migrate" {
+		t.Errorf("Expected pod to resolve to its Job, got %s", key)
+	}
+
+	if key := resolveOwnerKey(context.Background(), kubeClient, "default", nil, "fallback"); key != "fallback" {
+		t.Errorf("Expected resource without owner to use the fallback, got %s", key)
+	}
+}
+
+func TestAddTriggerBatchEnv(t *testing.T) {
+	job := &batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "first"}, {Name: "second"}},
+				},
+			},
+		},
+	}
+
+	triggers := []debouncedTrigger{
+		{ResourceKind: "Pod", ResourceName: "pod-a", ResourceNamespace: "default", TriggerType: "CREATE"},
+		{ResourceKind: "Pod", ResourceName: "pod-b", ResourceNamespace: "default", TriggerType: "CREATE"},
+	}
+	addTriggerBatchEnv(job, triggers)
+
+	for _, container := range job.Spec.Template.Spec.Containers {
+		env := make(map[string]string)
+		for _, e := range container.Env {
+			env[e.Name] = e.Value
+		}
+
+		if env["TRIGGER_COUNT"] != "2" {
+			t.Errorf("Expected TRIGGER_COUNT=2 in container %s, got %q", container.Name, env["TRIGGER_COUNT"])
+		}
+
+		var decoded []debouncedTrigger
+		if err := json.Unmarshal([]byte(env["TRIGGERS"]), &decoded); err != nil {
+			t.Fatalf("Failed to decode TRIGGERS in container %s: %v", container.Name, err)
+		}
+		if len(decoded) != 2 || decoded[1].ResourceName != "pod-b" {
+			t.Errorf("Unexpected TRIGGERS payload in container %s: %v", container.Name, decoded)
+		}
+	}
+
+	// Jobs outside of a debounced batch are left untouched
+	plainJob := &batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "only"}}},
+			},
+		},
+	}
+	addTriggerBatchEnv(plainJob, nil)
+	if len(plainJob.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("Expected no env vars without a batch")
+	}
+}
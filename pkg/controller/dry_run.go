@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// isDryRun checks if the template's jobs are only created with a server-side dry run, either because the
+// controller runs with --dry-run or because the template sets dryRun
+func isDryRun(options Options, template *v1alpha1.EventTriggeredJob) bool {
+	return options.DryRun || template.Spec.DryRun
+}
+
+// dryRunOption returns the dryRun option of requests that change jobs
+func dryRunOption(dryRun bool) []string {
+	if dryRun {
+		return []string{metav1.DryRunAll}
+	}
+
+	return nil
+}
+
+// dryRunJobName returns the namespace and name of a job returned by a dry-run create. The name is only
+// generated by API servers, so fall back to the prefix it would have been generated from.
+func dryRunJobName(job *batchv1.Job) string {
+	name := job.Name
+	if name == "" {
+		name = job.GenerateName + "*"
+	}
+
+	return job.Namespace + "/" + name
+}
+
+// recordDryRunJob logs, counts and records in the template's status a job that a dry run would have created
+func recordDryRunJob(
+	ctx context.Context,
+	kubananaClient versioned.Interface,
+	recorder record.EventRecorder,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	involved *corev1.ObjectReference,
+	job *batchv1.Job,
+	trigger string) {
+
+	name := dryRunJobName(job)
+	klog.FromContext(ctx).Info("Dry run: would have created job", logKeyJob, name)
+	metrics.JobDryRun(template.Namespace, template.Name, trigger)
+	recordTriggerEvent(recorder, options, template, involved, corev1.EventTypeNormal, reasonJobDryRun,
+		"Dry run: would have created job %s for %s %s/%s", name, involved.Kind, involved.Namespace, involved.Name)
+
+	err := updateTemplateStatus(ctx, kubananaClient, template, func(status *v1alpha1.EventTriggeredJobStatus) {
+		status.DryRunJobs++
+		status.LastDryRunJob = name
+	})
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to update template status")
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestIsDryRun(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	if isDryRun(Options{}, template) {
+		t.Error("Expected templates to create jobs by default")
+	}
+	if !isDryRun(Options{DryRun: true}, template) {
+		t.Error("Expected --dry-run to apply to every template")
+	}
+
+	template.Spec.DryRun = true
+	if !isDryRun(Options{}, template) {
+		t.Error("Expected the template's dryRun to apply without --dry-run")
+	}
+	if options := dryRunOption(true); len(options) != 1 || options[0] != metav1.DryRunAll {
+		t.Errorf("Expected dryRun=All, got %v", options)
+	}
+	if options := dryRunOption(false); options != nil {
+		t.Errorf("Expected no dryRun option, got %v", options)
+	}
+}
+
+func TestRecordDryRunJob(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec:       v1alpha1.EventTriggeredJobSpec{DryRun: true},
+		Status:     v1alpha1.EventTriggeredJobStatus{JobsCreated: 1, DryRunJobs: 1},
+	}
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+	recorder := record.NewFakeRecorder(10)
+	involved := &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{GenerateName: "test-template-web-1-", Namespace: "default"}}
+
+	recordDryRunJob(context.Background(), kubananaClient, recorder, Options{}, template, involved, job, eventTrigger)
+
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if updated.Status.DryRunJobs != 2 || updated.Status.LastDryRunJob != "default/test-template-web-1-*" {
+		t.Errorf("Expected a second dry-run job named after its prefix, got %+v", updated.Status)
+	}
+	if updated.Status.JobsCreated != 1 {
+		t.Errorf("Expected dry-run jobs not to count as created, got %d", updated.Status.JobsCreated)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, reasonJobDryRun) {
+			t.Errorf("Expected a %s event, got %q", reasonJobDryRun, event)
+		}
+	default:
+		t.Error("Expected a JobDryRun event")
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	kubananalisters "github.com/roshbhatia/kubanana/pkg/client/listers/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,103 +21,206 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
 type EventController struct {
-	kubeClient kubernetes.Interface
-	workqueue  workqueue.RateLimitingInterface
-	informer   cache.SharedIndexInformer
+	kubeClient       kubernetes.Interface
+	kubananaClient   versioned.Interface
+	workqueue        workqueue.RateLimitingInterface
+	informer         *namespacedInformer
+	templateInformer *namespacedInformer
+	templateLister   kubananalisters.EventTriggeredJobLister
+	namespaceAccess  *namespaceAccess
+	options          Options
+	limiter          *triggerLimiter
+	debouncer        *debouncer
+	suspended        *suspendQueue
+	history          *triggerHistory
+	dropped          *droppedTriggers
+	standby          atomic.Bool
+	replaySince      time.Time
+	health           workerHealth
+	recorder         record.EventRecorder
 }
 
-func NewEventController(kubeClient kubernetes.Interface) *EventController {
-	// Create handler functions for listing and watching events
-	listFunc := func(options metav1.ListOptions) (runtime.Object, error) {
-		return kubeClient.CoreV1().Events("").List(context.Background(), options)
-	}
-	watchFunc := func(options metav1.ListOptions) (watch.Interface, error) {
-		return kubeClient.CoreV1().Events("").Watch(context.Background(), options)
-	}
+// NewEventController creates a new EventController with default options
+func NewEventController(kubeClient kubernetes.Interface, kubananaClient versioned.Interface) *EventController {
+	return NewEventControllerWithOptions(kubeClient, kubananaClient, Options{})
+}
 
-	informer := cache.NewSharedIndexInformer(
-		&cache.ListWatch{
-			ListFunc:  listFunc,
-			WatchFunc: watchFunc,
-		},
-		&corev1.Event{},
-		0,
-		cache.Indexers{},
-	)
+// NewEventControllerWithOptions creates a new EventController with the given options
+func NewEventControllerWithOptions(
+	kubeClient kubernetes.Interface,
+	kubananaClient versioned.Interface,
+	options Options) *EventController {
+
+	// Watch events in each watched namespace, or in all namespaces if they aren't restricted
+	informer := newNamespacedInformer(watchNamespaces(options), func(namespace string) cache.SharedIndexInformer {
+		// Create handler functions for listing and watching events
+		listFunc := func(options metav1.ListOptions) (runtime.Object, error) {
+			return kubeClient.CoreV1().Events(namespace).List(context.Background(), options)
+		}
+		watchFunc := func(options metav1.ListOptions) (watch.Interface, error) {
+			return kubeClient.CoreV1().Events(namespace).Watch(context.Background(), options)
+		}
+
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc:  listFunc,
+				WatchFunc: watchFunc,
+			},
+			&corev1.Event{},
+			options.EventResyncPeriod,
+			cache.Indexers{},
+		)
+	})
 
-	workqueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	workqueue := workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(),
+		workqueue.RateLimitingQueueConfig{Name: "events"})
+	metrics.RegisterInformer(corev1.SchemeGroupVersion.WithKind("Event").String(), informer)
 
+	templateInformer, templateLister := newTemplateInformer(kubananaClient, watchNamespaces(options), options.EventResyncPeriod)
+
+	recorder := newEventRecorder(kubeClient)
 	controller := &EventController{
-		kubeClient: kubeClient,
-		informer:   informer,
-		workqueue:  workqueue,
+		kubeClient:       kubeClient,
+		kubananaClient:   kubananaClient,
+		informer:         informer,
+		templateInformer: templateInformer,
+		templateLister:   templateLister,
+		namespaceAccess:  newNamespaceAccess(kubeClient, watchNamespaces(options)),
+		workqueue:        workqueue,
+		options:          options,
+		limiter:          newTriggerLimiter(),
+		debouncer:        newDebouncer(eventTrigger),
+		suspended:        newSuspendQueue(),
+		history:          newTriggerHistory(kubananaClient, options.TriggerHistoryLimit),
+		dropped:          newDroppedTriggers(kubananaClient, recorder),
+		recorder:         recorder,
 	}
 
 	// Using AddEventHandlerWithResyncPeriod which doesn't return a value in our version
 	informer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleEvent,
 		UpdateFunc: func(old, new interface{}) {
+			// Resyncs only refresh the cache, an event must not trigger templates again
+			if old.(*corev1.Event).ResourceVersion == new.(*corev1.Event).ResourceVersion {
+				return
+			}
 			controller.handleEvent(new)
 		},
 		DeleteFunc: controller.handleEvent,
-	}, 0)
-
-	// Load initial templates if not in a test environment
-	// In test environments, the RESTClient might not be fully mocked
-	_, isTest := os.LookupEnv("TEST_MODE")
-	if !isTest {
-		controller.refreshTemplates()
-	}
+	}, options.EventResyncPeriod)
+	templateInformer.AddEventHandlerWithResyncPeriod(
+		suspendedConditionHandler(kubananaClient, &controller.standby), options.EventResyncPeriod)
 
 	return controller
 }
 
-// refreshTemplates loads all EventTriggeredJobs from the k8s API
-func (c *EventController) refreshTemplates() {
-	// Skip for testing when kubeClient might not be fully initialized
-	if c.kubeClient == nil {
-		klog.Warningf("Skipping refresh templates, kubeClient is nil")
-		return
-	}
-
-	templateList, err := c.kubeClient.CoreV1().RESTClient().
-		Get().
-		AbsPath("/apis/kubanana.roshanbhatia.com/v1alpha1/eventtriggeredjobs").
-		DoRaw(context.Background())
-
-	if err != nil {
-		klog.Errorf("Failed to get templates: %v", err)
-		return
+// Run starts the controller and processes triggers until stopCh is closed
+func (c *EventController) Run(workers int, stopCh <-chan struct{}) error {
+	if err := c.Start(stopCh); err != nil {
+		c.workqueue.ShutDown()
+		return err
 	}
 
-	klog.Infof("Loaded templates: %s", string(templateList))
+	c.RunWorkers(workers, stopCh)
+	return nil
 }
 
-func (c *EventController) Run(workers int, stopCh <-chan struct{}) error {
-	defer c.workqueue.ShutDown()
+// Start runs the informer and waits for its cache to sync. Until RunWorkers is called the
+// controller is a standby that keeps its cache warm without processing any triggers.
+func (c *EventController) Start(stopCh <-chan struct{}) error {
+	c.standby.Store(true)
+	c.replaySince = time.Now()
 
-	klog.Info("Starting event controller")
+	klog.InfoS("Starting event controller")
 
 	go c.informer.Run(stopCh)
+	go c.templateInformer.Run(stopCh)
+	go c.namespaceAccess.informer.Run(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced, c.templateInformer.HasSynced,
+		c.namespaceAccess.informer.HasSynced) {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
-	klog.Info("Event controller synced and ready")
+	klog.InfoS("Event controller synced and ready")
+	return nil
+}
+
+// RunWorkers processes triggers until stopCh is closed
+func (c *EventController) RunWorkers(workers int, stopCh <-chan struct{}) {
+	defer c.workqueue.ShutDown()
+
+	c.standby.Store(false)
+	klog.InfoS("Event controller processing triggers")
+	reconcileSuspendedConditions(c.kubananaClient, c.templateLister)
+	c.replayStandbyEvents()
 
 	for i := 0; i < workers; i++ {
-		go wait.Until(c.runWorker, time.Second, stopCh)
+		go c.health.run(fmt.Sprintf("event-%d", i), func() {
+			wait.Until(c.runWorker, time.Second, stopCh)
+		})
 	}
 
+	go c.health.run("event-resume", func() {
+		wait.Until(c.resumeQueuedTriggers, suspendResyncPeriod, stopCh)
+	})
+
 	<-stopCh
-	klog.Info("Shutting down event controller")
-	return nil
+	klog.InfoS("Shutting down event controller")
+	c.debouncer.stop()
+	c.history.flushAll()
+	c.dropped.flushAll()
+}
+
+// ReplaySince sets when the previous leader stopped handling triggers, e.g. its last lease renewal.
+// Events seen on standby after that are replayed once RunWorkers is called. It defaults to when
+// the controller was started.
+func (c *EventController) ReplaySince(since time.Time) {
+	c.replaySince = since
+}
+
+// replayStandbyEvents queues the cached events last seen at or after the replay time, to the second
+// event timestamps are stored with. Standbys don't queue events, so this covers the ones that arrived
+// while no replica was handling them.
+func (c *EventController) replayStandbyEvents() {
+	if c.replaySince.IsZero() {
+		return
+	}
+
+	since := c.replaySince.Truncate(time.Second)
+	replayed := 0
+	for _, key := range c.informer.ListKeys() {
+		item, exists, err := c.informer.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		event, ok := item.(*corev1.Event)
+		if !ok || isOwnEvent(event) || eventLastSeen(event).Before(since) {
+			continue
+		}
+
+		metrics.EventReceived(eventTrigger)
+		c.workqueue.Add(key)
+		replayed++
+	}
+
+	klog.InfoS("Replayed events seen on standby", "count", replayed, "since", c.replaySince)
+}
+
+// HasSynced checks if the event, template and namespace informers have synced their caches
+func (c *EventController) HasSynced() bool {
+	return c.informer.HasSynced() && c.templateInformer.HasSynced() && c.namespaceAccess.informer.HasSynced()
+}
+
+// Healthy returns an error if one of the controller's workers died
+func (c *EventController) Healthy() error {
+	return c.health.check()
 }
 
 func (c *EventController) runWorker() {
@@ -133,151 +241,325 @@ func (c *EventController) processNextItem() bool {
 
 	key, ok := obj.(string)
 	if !ok {
-		klog.Errorf("Expected string in workqueue but got %#v", obj)
+		klog.ErrorS(nil, "Expected string in workqueue", "item", obj)
 		c.workqueue.Forget(obj)
 		return true
 	}
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		klog.Errorf("Invalid key: %s", key)
+		klog.ErrorS(err, "Invalid workqueue key", "key", key)
 		c.workqueue.Forget(obj)
 		return true
 	}
 
-	event, exists, err := c.informer.GetStore().GetByKey(key)
+	event, exists, err := c.informer.GetByKey(key)
 	if err != nil {
-		klog.Errorf("Failed to get event %s from store: %v", key, err)
+		klog.ErrorS(err, "Failed to get event from store", "event", key)
 		c.workqueue.AddRateLimited(obj)
 		return true
 	}
 
 	if !exists {
-		klog.V(4).Infof("Event %s no longer exists", key)
+		klog.V(4).InfoS("Event no longer exists", "event", key)
 		c.workqueue.Forget(obj)
 		return true
 	}
 
 	eventObj, ok := event.(*corev1.Event)
 	if !ok {
-		klog.Errorf("Failed to convert event object: %v", event)
+		klog.ErrorS(nil, "Failed to convert event object", "event", key)
 		c.workqueue.Forget(obj)
 		return true
 	}
 
 	if err = c.processEvent(eventObj); err != nil {
-		klog.Errorf("Failed to process event %s: %v", key, err)
+		klog.ErrorS(err, "Failed to process event", "event", key)
 		c.workqueue.AddRateLimited(obj)
 		return true
 	}
 
 	c.workqueue.Forget(obj)
-	klog.V(4).Infof("Successfully processed event %s/%s", namespace, name)
+	klog.V(4).InfoS("Successfully processed event", "event", klog.KRef(namespace, name))
 	return true
 }
 
 func (c *EventController) processEvent(event *corev1.Event) error {
-	klog.V(4).Infof("Processing event: %s/%s, Resource: %s/%s, Reason: %s",
-		event.Namespace, event.Name,
-		event.InvolvedObject.Kind, event.InvolvedObject.Name,
-		event.Reason)
+	resourceKey := triggerResource(event.InvolvedObject.Kind, event.InvolvedObject.Namespace, event.InvolvedObject.Name)
+	logger := klog.LoggerWithValues(klog.Background(), logKeyTrigger, eventTrigger, logKeyResource, resourceKey)
+	logger.V(4).Info("Processing event", "event", klog.KObj(event), "reason", event.Reason)
 
 	// Determine event type
 	eventType := determineEventType(event)
 	if eventType == "" {
-		klog.V(4).Infof("Couldn't determine event type, skipping")
+		logger.V(4).Info("Couldn't determine event type, skipping", "reason", event.Reason)
 		return nil
 	}
 
 	// For tests, skip the template retrieval
 	if os.Getenv("TEST_MODE") == "true" {
-		klog.V(4).Infof("Running in test mode, skipping template retrieval")
+		logger.V(4).Info("Running in test mode, skipping template retrieval")
 		return nil
 	}
 
-	// Get all templates to check for matches
-	templateList := &v1alpha1.EventTriggeredJobList{}
-	err := c.kubeClient.CoreV1().RESTClient().
-		Get().
-		AbsPath("/apis/kubanana.roshanbhatia.com/v1alpha1/eventtriggeredjobs").
-		Do(context.Background()).
-		Into(templateList)
+	ctx, span := startSpan(context.Background(), spanReceiveEvent, append(triggerAttributes(eventTrigger, resourceKey),
+		attribute.String("kubanana.event.type", eventType),
+		attribute.String("kubanana.event.reason", event.Reason))...)
+	defer span.End()
 
+	// Get all templates to check for matches
+	templates, err := listTemplates(c.templateLister)
 	if err != nil {
-		klog.Errorf("Failed to fetch templates: %v", err)
+		logger.Error(err, "Failed to list templates")
+		endSpan(span, err)
 		return err
 	}
 
 	// For each template, check if it matches the event
 	matchFound := false
-	for _, template := range templateList.Items {
+	var origin *triggerOrigin
+	resolveOrigin := func() triggerOrigin {
+		if origin == nil {
+			origin = c.resolveEventOrigin(event)
+		}
+		return *origin
+	}
+	for _, template := range templates {
 		// Skip templates without an EventSelector
 		if template.Spec.EventSelector == nil {
 			continue
 		}
 
-		// Check if the resource kind matches
-		if template.Spec.EventSelector.ResourceKind != event.InvolvedObject.Kind {
-			klog.V(4).Infof("Skipping template %s: resource kind doesn't match (%s != %s)",
-				template.Name, template.Spec.EventSelector.ResourceKind, event.InvolvedObject.Kind)
-			continue
+		if c.evaluateTemplate(ctx, logger, template, event, eventType, resourceKey, resolveOrigin) {
+			matchFound = true
 		}
+	}
 
-		// Check if the event type matches any in the template
-		eventTypeMatch := false
-		for _, allowedType := range template.Spec.EventSelector.EventTypes {
-			if allowedType == eventType {
-				eventTypeMatch = true
-				break
-			}
-		}
-		if !eventTypeMatch {
-			klog.V(4).Infof("Skipping template %s: event type doesn't match", template.Name)
-			continue
-		}
+	if !matchFound {
+		logger.V(4).Info("No matching templates found for event", "event", klog.KObj(event))
+	}
 
-		// Check name pattern if specified
-		if template.Spec.EventSelector.NamePattern != "" {
-			if !matchNamePattern(template.Spec.EventSelector.NamePattern, event.InvolvedObject.Name) {
-				klog.V(4).Infof("Skipping template %s: name pattern doesn't match", template.Name)
-				continue
-			}
-		}
+	return nil
+}
 
-		// Check namespace pattern if specified
-		if template.Spec.EventSelector.NamespacePattern != "" {
-			if !matchNamePattern(template.Spec.EventSelector.NamespacePattern, event.InvolvedObject.Namespace) {
-				klog.V(4).Infof("Skipping template %s: namespace pattern doesn't match", template.Name)
-				continue
-			}
-		}
+// evaluateTemplate runs template if it matches the event, and reports whether it matched
+func (c *EventController) evaluateTemplate(
+	ctx context.Context,
+	logger klog.Logger,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType, resourceKey string,
+	resolveOrigin func() triggerOrigin) bool {
+
+	ctx, span := startSpan(ctx, spanEvaluateTemplate, templateAttributes(template)...)
+	defer span.End()
+	metrics.TemplateEvaluated(template.Namespace, template.Name, eventTrigger)
+
+	// Check if the resource kind matches
+	if template.Spec.EventSelector.ResourceKind != event.InvolvedObject.Kind {
+		logger.V(4).Info("Skipping template, resource kind doesn't match", logKeyTemplate, klog.KObj(template),
+			"resourceKind", template.Spec.EventSelector.ResourceKind)
+		return false
+	}
 
-		// Check label selector if specified
-		if template.Spec.EventSelector.LabelSelector != nil {
-			// TODO: Implement label selector matching
-			// This requires getting the actual object to check its labels
-			klog.V(4).Infof("Label selector matching not implemented yet")
-		}
+	// Check the event type, name and namespace patterns
+	if result := MatchEvent(template, event); !result.Matched() {
+		logger.V(4).Info("Skipping template", logKeyTemplate, klog.KObj(template), "reason", result.Reason())
+		return false
+	}
 
-		// Template matched, create a job
-		klog.Infof("Template %s matched event for %s/%s, creating job",
-			template.Name, event.InvolvedObject.Kind, event.InvolvedObject.Name)
+	// Templates only observe other namespaces if their own namespace grants it
+	if !c.namespaceAccess.canObserve(template, event.InvolvedObject.Namespace) {
+		logger.V(4).Info("Skipping template, namespace can't be observed from the template's namespace",
+			logKeyTemplate, klog.KObj(template))
+		return false
+	}
 
-		matchFound = true
+	// Only warn about an invalid template once an event matches it
+	if !checkTemplateValid(c.recorder, template) {
+		return false
+	}
 
-		// Create job based on the template
-		if err := c.createJobFromTemplate(&template, event, eventType); err != nil {
-			klog.Errorf("Failed to create job from template %s: %v", template.Name, err)
-			continue
+	ctx = withTrigger(ctx, template, eventTrigger, resourceKey)
+
+	// Objects Kubanana created itself only trigger templates that allow it
+	origin := resolveOrigin()
+	if allowed, reason := checkSelfTrigger(template, origin); !allowed {
+		if reason != "" {
+			recordDroppedTrigger(ctx, c.dropped, template, eventTrigger, reason)
+			c.auditTrigger(ctx, template, event, eventType, v1alpha1.DroppedTriggerOutcome, "", reason)
 		}
+		return false
 	}
 
-	if !matchFound {
-		klog.V(4).Infof("No matching templates found for event %s/%s",
-			event.Namespace, event.Name)
+	// Template matched, create a job
+	klog.FromContext(ctx).Info("Template matched event, creating job", "eventType", eventType)
+	span.SetAttributes(attribute.Bool("kubanana.matched", true))
+	metrics.TemplateMatched(template.Namespace, template.Name, eventTrigger)
+
+	c.runTemplate(ctx, template, event, eventType, origin)
+	return true
+}
+
+// resolveEventOrigin checks if the object an event is about was created by Kubanana
+func (c *EventController) resolveEventOrigin(event *corev1.Event) *triggerOrigin {
+	ref := event.InvolvedObject
+	objMeta, err := lookupObjectMeta(context.Background(), c.kubeClient, ref.Kind, ref.Namespace, ref.Name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get resource to resolve its origin",
+			logKeyResource, triggerResource(ref.Kind, ref.Namespace, ref.Name), "err", err)
+		return &triggerOrigin{}
 	}
 
-	return nil
+	origin := resolveTriggerOrigin(context.Background(), c.kubeClient, c.options, objMeta)
+	return &origin
+}
+
+// runTemplate runs a template that matched the event unless the template is suspended
+func (c *EventController) runTemplate(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	origin triggerOrigin) {
+
+	// Queued triggers outlive this call, so they get their own copy of the event
+	queuedEvent := event.DeepCopy()
+	run := func(latest *v1alpha1.EventTriggeredJob) {
+		c.runTemplate(ctx, latest, queuedEvent, eventType, origin)
+	}
+
+	if checkSuspended(ctx, c.dropped, c.suspended, template, eventTrigger, run) {
+		c.auditTrigger(ctx, template, event, eventType, suspendedOutcome(template), "", dropReasonSuspended)
+		return
+	}
+
+	// Collect bursty triggers into a single job if the template is debounced
+	if template.Spec.Debounce != nil {
+		c.debounceTrigger(ctx, template, event, eventType, origin)
+		return
+	}
+
+	c.triggerJob(ctx, template, event, eventType, origin, nil)
+}
+
+// resumeQueuedTriggers runs the queued triggers of templates that were resumed
+func (c *EventController) resumeQueuedTriggers() {
+	c.suspended.resume(func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return fetchTemplate(context.Background(), c.kubananaClient, namespace, name)
+	})
+}
+
+// triggerJob enforces the template's guardrails and creates a job for the event.
+// batch holds the coalesced triggers if the job is created for a debounced batch.
+func (c *EventController) triggerJob(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	origin triggerOrigin,
+	batch []debouncedTrigger) {
+
+	// Enforce the template's rate limits before touching any existing jobs. The slot reserved for the
+	// trigger is released again unless a job is created.
+	resourceKey := triggerResource(event.InvolvedObject.Kind, event.InvolvedObject.Namespace, event.InvolvedObject.Name)
+	release, allowed, reason := c.limiter.allow(template, resourceKey)
+	if !allowed {
+		recordDroppedTrigger(ctx, c.dropped, template, eventTrigger, reason)
+		c.auditTrigger(ctx, template, event, eventType, v1alpha1.DroppedTriggerOutcome, "", reason)
+		return
+	}
+
+	// Enforce the template's concurrency policy against previously created jobs
+	proceed, err := applyConcurrencyPolicy(ctx, c.kubeClient, c.options, template,
+		jobNamespace(template, event.InvolvedObject.Namespace, eventJobNamespacePolicy), event.InvolvedObject)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to apply concurrency policy")
+		c.auditTrigger(ctx, template, event, eventType, v1alpha1.JobFailedTriggerOutcome, "", err.Error())
+		release()
+		return
+	}
+	if !proceed {
+		recordDroppedTrigger(ctx, c.dropped, template, eventTrigger, dropReasonConcurrencyForbidden)
+		c.auditTrigger(ctx, template, event, eventType, v1alpha1.DroppedTriggerOutcome, "", dropReasonConcurrencyForbidden)
+		release()
+		return
+	}
+
+	// Create job based on the template
+	involved := event.InvolvedObject.DeepCopy()
+	job, err := c.createJobFromTemplate(ctx, template, event, eventType, origin, batch)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to create job from template")
+		metrics.JobCreationFailed(template.Namespace, template.Name, eventTrigger)
+		recordTriggerEvent(c.recorder, c.options, template, involved, corev1.EventTypeWarning, reasonJobCreationFailed,
+			"Failed to create job for %s %s/%s: %v", involved.Kind, involved.Namespace, involved.Name, err)
+		c.auditTrigger(ctx, template, event, eventType, v1alpha1.JobFailedTriggerOutcome, "", err.Error())
+		release()
+		return
+	}
+	if isDryRun(c.options, template) {
+		recordDryRunJob(ctx, c.kubananaClient, c.recorder, c.options, template, involved, job, eventTrigger)
+		c.auditTrigger(ctx, template, event, eventType, v1alpha1.JobDryRunTriggerOutcome, dryRunJobName(job), "")
+		release()
+		return
+	}
+	metrics.JobCreated(template.Namespace, template.Name, eventTrigger)
+	recordCreatedJob(ctx, c.kubananaClient, template)
+	recordTriggerEvent(c.recorder, c.options, template, involved, corev1.EventTypeNormal, reasonJobTriggered,
+		"Created job %s/%s for %s %s/%s", job.Namespace, job.Name, involved.Kind, involved.Namespace, involved.Name)
+	c.auditTrigger(ctx, template, event, eventType, v1alpha1.JobCreatedTriggerOutcome, jobRef(job), "")
+}
+
+// auditTrigger records the outcome of the event triggering the template
+func (c *EventController) auditTrigger(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	outcome v1alpha1.TriggerOutcome,
+	job, reason string) {
+
+	record := newTriggerRecord(eventTrigger, eventType, &event.InvolvedObject, MatchEvent(template, event), outcome, job, reason)
+	writeTriggerRecord(ctx, c.history, c.options, template, record)
+}
+
+// debounceTrigger collects the event into the template's current debounce batch
+func (c *EventController) debounceTrigger(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	origin triggerOrigin) {
+
+	resourceKey := triggerResource(event.InvolvedObject.Kind, event.InvolvedObject.Namespace, event.InvolvedObject.Name)
+
+	ownerKey := resourceKey
+	if template.Spec.Debounce.Key == v1alpha1.OwnerDebounceKey {
+		ownerRefs := lookupOwnerReferences(ctx, c.kubeClient, event.InvolvedObject)
+		ownerKey = resolveOwnerKey(ctx, c.kubeClient, event.InvolvedObject.Namespace, ownerRefs, resourceKey)
+	}
+
+	trigger := debouncedTrigger{
+		ResourceKind:      event.InvolvedObject.Kind,
+		ResourceName:      event.InvolvedObject.Name,
+		ResourceNamespace: event.InvolvedObject.Namespace,
+		TriggerType:       eventType,
+		Time:              metav1.Now(),
+	}
+
+	c.auditTrigger(ctx, template, event, eventType, v1alpha1.DebouncedTriggerOutcome, "", "")
+
+	// The batch outlives this call, so hand it copies of the template and event
+	template = template.DeepCopy()
+	event = event.DeepCopy()
+
+	c.debouncer.add(debounceKey(template, resourceKey, ownerKey), template.Spec.Debounce.Window.Duration, trigger,
+		func(triggers []debouncedTrigger) {
+			klog.FromContext(ctx).Info("Debounce window closed, creating job", "triggers", len(triggers))
+			metrics.TriggerSkipped(template.Namespace, template.Name, eventTrigger, dropReasonDebounced, len(triggers)-1)
+			c.triggerJob(ctx, template, event, eventType, origin, triggers)
+		})
 }
 
 // determineEventType maps k8s event to CREATE, UPDATE, or DELETE
@@ -312,12 +594,40 @@ func determineEventType(event *corev1.Event) string {
 func (c *EventController) handleEvent(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if err != nil {
-		klog.Errorf("Failed to get key for object: %v", err)
+		klog.ErrorS(err, "Failed to get key for object")
 		return
 	}
+
+	// Standbys only keep their cache warm, the leader handles the event
+	if c.standby.Load() {
+		return
+	}
+
+	// Kubanana's own events must never trigger templates, or they'd feed back into themselves
+	if event, ok := obj.(*corev1.Event); ok && isOwnEvent(event) {
+		return
+	}
+
+	metrics.EventReceived(eventTrigger)
+
 	c.workqueue.Add(key)
 }
 
+// eventLastSeen returns when the event was last observed, falling back to when it was first
+// recorded for events that don't set a last timestamp
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
 // Helper function to check if a name matches a pattern
 func matchNamePattern(pattern, name string) bool {
 	// Empty pattern shouldn't match anything
@@ -385,7 +695,45 @@ func matchNamePattern(pattern, name string) bool {
 }
 
 // Create a job from a template
-func (c *EventController) createJobFromTemplate(template *v1alpha1.EventTriggeredJob, event *corev1.Event, eventType string) error {
+func (c *EventController) createJobFromTemplate(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	origin triggerOrigin,
+	batch []debouncedTrigger) (_ *batchv1.Job, err error) {
+
+	ctx, span := startSpan(ctx, spanCreateJob)
+	defer func() { endSpan(span, err) }()
+
+	job := newEventJob(template, event, eventType, origin, batch, c.options)
+
+	// Let the job's workload continue the trace of its trigger
+	injectTraceParent(ctx, job)
+
+	// Create the job as the template's service account
+	createdJob, err := createJob(ctx, c.kubeClient, c.options, template, job)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("kubanana.job", jobRef(createdJob)))
+
+	// Dry-run jobs are logged once their trigger is recorded
+	if !isDryRun(c.options, template) {
+		klog.FromContext(ctx).Info("Created job", logKeyJob, klog.KObj(createdJob))
+	}
+	return createdJob, nil
+}
+
+// newEventJob renders the job a template runs for an event, without creating it
+func newEventJob(
+	template *v1alpha1.EventTriggeredJob,
+	event *corev1.Event,
+	eventType string,
+	origin triggerOrigin,
+	batch []debouncedTrigger,
+	options Options) *batchv1.Job {
+
 	// Create job name based on template name and event type
 	jobName := fmt.Sprintf("%s-%s-%s",
 		template.Name,
@@ -394,29 +742,21 @@ func (c *EventController) createJobFromTemplate(template *v1alpha1.EventTriggere
 
 	// Create labels for the job
 	labels := map[string]string{
-		"kubanana-template":      template.Name,
-		"kubanana-resource-kind": event.InvolvedObject.Kind,
-		"kubanana-resource-name": event.InvolvedObject.Name,
-		"kubanana-event-type":    eventType,
+		TemplateLabel:          template.Name,
+		ResourceKindLabel:      event.InvolvedObject.Kind,
+		ResourceNamespaceLabel: event.InvolvedObject.Namespace,
+		ResourceNameLabel:      event.InvolvedObject.Name,
+		EventTypeLabel:         eventType,
 	}
 
 	// Create a job from the template
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: jobName + "-",
-			Namespace:    event.Namespace,
+			Namespace:    jobNamespace(template, event.InvolvedObject.Namespace, eventJobNamespacePolicy),
 			Labels:       labels,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion: "kubanana.roshanbhatia.com/v1alpha1",
-					Kind:       "EventTriggeredJob",
-					Name:       template.Name,
-					UID:        template.UID,
-					Controller: &[]bool{true}[0],
-				},
-			},
 		},
-		Spec: template.Spec.JobTemplate.Spec,
+		Spec: *template.Spec.JobTemplate.Spec.DeepCopy(),
 	}
 
 	// Apply variable substitution to the job spec
@@ -450,14 +790,19 @@ func (c *EventController) createJobFromTemplate(template *v1alpha1.EventTriggere
 		}
 	}
 
-	// Create the job
-	createdJob, err := c.kubeClient.BatchV1().Jobs(event.Namespace).Create(context.Background(), job, metav1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to create job: %w", err)
-	}
+	// Let the template own the job, or label it if the job lives in another namespace
+	setJobOwner(job, template)
 
-	klog.Infof("Created job %s/%s", createdJob.Namespace, createdJob.Name)
-	return nil
+	// Expose the coalesced triggers of a debounced batch
+	addTriggerBatchEnv(job, batch)
+
+	// Mark the job and its pods so they don't trigger templates in an endless loop
+	applyTriggerOrigin(job, template, origin)
+
+	// Fill in controller-wide defaults such as the job TTL
+	applyJobDefaults(job, options)
+
+	return job
 }
 
 // Substitute variables in a string
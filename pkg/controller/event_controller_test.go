@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,7 +27,7 @@ func TestNewEventController(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create a new event controller
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 
 	// Check if the controller is properly initialized
 	if controller.kubeClient != kubeClient {
@@ -47,7 +48,7 @@ func TestHandleEvent(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create a new event controller with a custom queue so we can inspect it
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 
 	// Create a test event
 	event := &corev1.Event{
@@ -83,12 +84,133 @@ func TestHandleEvent(t *testing.T) {
 	}
 }
 
+func TestHandleEventStandby(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-event",
+			Namespace: "default",
+		},
+	}
+
+	// Standbys keep their cache warm but leave the event to the leader
+	controller.standby.Store(true)
+	controller.handleEvent(event)
+	if controller.workqueue.Len() != 0 {
+		t.Errorf("Expected standby not to queue events, got %d items", controller.workqueue.Len())
+	}
+
+	controller.standby.Store(false)
+	controller.handleEvent(event)
+	if controller.workqueue.Len() != 1 {
+		t.Errorf("Expected leader to queue the event, got %d items", controller.workqueue.Len())
+	}
+}
+
+func TestReplayStandbyEvents(t *testing.T) {
+	controller := NewEventController(fake.NewSimpleClientset(), kubananafake.NewSimpleClientset())
+
+	now := metav1.Now()
+	old := metav1.NewTime(now.Add(-time.Hour))
+	events := []*corev1.Event{
+		// Seen after the previous leader's last lease renewal
+		{ObjectMeta: metav1.ObjectMeta{Name: "recent", Namespace: "default"}, LastTimestamp: now},
+		// A recurring event first recorded long ago but seen again recently
+		{ObjectMeta: metav1.ObjectMeta{Name: "recurring", Namespace: "default", CreationTimestamp: old},
+			Series: &corev1.EventSeries{LastObservedTime: metav1.NewMicroTime(now.Time)}},
+		// Handled by the previous leader before it went away
+		{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default"}, LastTimestamp: old},
+		{ObjectMeta: metav1.ObjectMeta{Name: "own", Namespace: "default"}, LastTimestamp: now,
+			Source: corev1.EventSource{Component: eventSourceComponent}},
+	}
+	store := controller.informer.informers[metav1.NamespaceAll].GetStore()
+	for _, event := range events {
+		if err := store.Add(event); err != nil {
+			t.Fatalf("Failed to add event to store: %v", err)
+		}
+	}
+
+	// Without Start or a previous leader there's nothing to replay
+	controller.replayStandbyEvents()
+	if controller.workqueue.Len() != 0 {
+		t.Fatalf("Expected no events to be replayed, got %d items", controller.workqueue.Len())
+	}
+
+	controller.ReplaySince(now.Add(-time.Minute))
+	controller.replayStandbyEvents()
+
+	queued := map[string]bool{}
+	for controller.workqueue.Len() > 0 {
+		item, _ := controller.workqueue.Get()
+		queued[item.(string)] = true
+		controller.workqueue.Done(item)
+	}
+	if len(queued) != 2 || !queued["default/recent"] || !queued["default/recurring"] {
+		t.Errorf("Expected only the events after the previous leader's renewal to be replayed, got %v", queued)
+	}
+}
+
+func TestStartReplaysEventsSeenWhileSyncing(t *testing.T) {
+	// Events that were already there when the controller started are history, but events recorded
+	// while its cache synced must still trigger templates once the workers run
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default"},
+			LastTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "syncing", Namespace: "default"},
+			LastTimestamp: metav1.NewTime(time.Now().Add(time.Second))},
+	)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if err := controller.Start(stopCh); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	if controller.workqueue.Len() != 0 {
+		t.Fatalf("Expected the standby not to queue events, got %d items", controller.workqueue.Len())
+	}
+
+	controller.replayStandbyEvents()
+	if controller.workqueue.Len() != 1 {
+		t.Fatalf("Expected the event recorded while syncing to be replayed, got %d items", controller.workqueue.Len())
+	}
+	if item, _ := controller.workqueue.Get(); item != "default/syncing" {
+		t.Errorf("Expected default/syncing to be replayed, got %v", item)
+	}
+}
+
+func TestHandleEventIgnoresOwnEvents(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template.17a",
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "EventTriggeredJob",
+			Name:      "test-template",
+			Namespace: "default",
+		},
+		Reason: reasonJobTriggered,
+		Source: corev1.EventSource{Component: eventSourceComponent},
+	}
+
+	controller.handleEvent(event)
+	if controller.workqueue.Len() != 0 {
+		t.Errorf("Expected our own event to be ignored, got %d items", controller.workqueue.Len())
+	}
+}
+
 func TestProcessNextItem(t *testing.T) {
 	// Create a fake kubernetes client
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create a new event controller
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 
 	// Replace the workqueue with a test queue
 	controller.workqueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
@@ -115,7 +237,7 @@ func TestRunWorker(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create a new event controller with a test queue
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 	controller.workqueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 
 	// Add a few items to the queue
@@ -153,7 +275,7 @@ func TestRun(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create a new event controller
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 
 	// Create a stop channel that we'll close after a short time
 	stopCh := make(chan struct{})
@@ -222,7 +344,7 @@ func TestEventHandling(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset(objects...)
 
 	// Create a new event controller
-	controller := NewEventController(kubeClient)
+	controller := NewEventController(kubeClient, kubananafake.NewSimpleClientset())
 
 	// Create a custom informer and replace the controller's informer
 	informer := cache.NewSharedIndexInformer(
@@ -238,7 +360,9 @@ func TestEventHandling(t *testing.T) {
 		0,
 		cache.Indexers{},
 	)
-	controller.informer = informer
+	controller.informer = newNamespacedInformer([]string{metav1.NamespaceAll}, func(string) cache.SharedIndexInformer {
+		return informer
+	})
 
 	// Add the event to the informer's store
 	err := informer.GetStore().Add(event)
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// eventSourceComponent is the component Kubanana's own events are reported by
+const eventSourceComponent = "kubanana-controller"
+
+// Reasons of the events Kubanana emits
+const (
+	reasonJobTriggered      = "JobTriggered"
+	reasonJobDryRun         = "JobDryRun"
+	reasonJobCreationFailed = "JobCreationFailed"
+	reasonTemplateInvalid   = "TemplateInvalid"
+	reasonTriggerSkipped    = "TriggerSkipped"
+)
+
+// eventScheme knows the kinds Kubanana emits events on
+var eventScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(eventScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(eventScheme))
+}
+
+// newEventRecorder creates an EventRecorder that writes Kubanana's events to the API server
+func newEventRecorder(kubeClient kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartStructuredLogging(4)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+
+	return broadcaster.NewRecorder(eventScheme, corev1.EventSource{Component: eventSourceComponent})
+}
+
+// isOwnEvent checks if an event was emitted by Kubanana itself
+func isOwnEvent(event *corev1.Event) bool {
+	return event.Source.Component == eventSourceComponent || event.ReportingController == eventSourceComponent
+}
+
+// recordTriggerEvent emits an event on the template and, if enabled in options, on the object
+// that triggered it
+func recordTriggerEvent(
+	recorder record.EventRecorder,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	involved *corev1.ObjectReference,
+	eventType, reason, messageFmt string,
+	args ...interface{}) {
+
+	recorder.Eventf(template, eventType, reason, messageFmt, args...)
+
+	if options.InvolvedObjectEvents && involved != nil {
+		message := fmt.Sprintf(messageFmt, args...)
+		recorder.Eventf(involved, eventType, reason, "%s (template %s/%s)", message, template.Namespace, template.Name)
+	}
+}
+
+// checkTemplateValid reports whether the template can be used, emitting TemplateInvalid if it can't
+func checkTemplateValid(recorder record.EventRecorder, template *v1alpha1.EventTriggeredJob) bool {
+	errs := validation.ValidateTemplate(template)
+	if len(errs) == 0 {
+		return true
+	}
+
+	klog.ErrorS(errs.ToAggregate(), "Template is invalid", logKeyTemplate, klog.KObj(template))
+	recorder.Eventf(template, corev1.EventTypeWarning, reasonTemplateInvalid, "Template is invalid: %v", errs.ToAggregate())
+	return false
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// newEventsTestTemplate creates a template that passes validation
+func newEventsTestTemplate() *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			EventSelector: &v1alpha1.EventSelector{
+				ResourceKind: "Pod",
+				EventTypes:   []string{"CREATE"},
+			},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "test", Image: "busybox"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// drainEvents returns all events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestIsOwnEvent(t *testing.T) {
+	own := &corev1.Event{Source: corev1.EventSource{Component: eventSourceComponent}}
+	if !isOwnEvent(own) {
+		t.Errorf("Expected event from %s to be recognized", eventSourceComponent)
+	}
+
+	reported := &corev1.Event{ReportingController: eventSourceComponent}
+	if !isOwnEvent(reported) {
+		t.Errorf("Expected event reported by %s to be recognized", eventSourceComponent)
+	}
+
+	other := &corev1.Event{Source: corev1.EventSource{Component: "kubelet"}}
+	if isOwnEvent(other) {
+		t.Errorf("Expected kubelet event not to be recognized as our own")
+	}
+}
+
+func TestRecordTriggerEvent(t *testing.T) {
+	template := newEventsTestTemplate()
+	involved := &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "test-pod"}
+
+	recorder := record.NewFakeRecorder(10)
+	recordTriggerEvent(recorder, Options{}, template, involved, corev1.EventTypeNormal, reasonJobTriggered,
+		"Created job %s", "default/test-job")
+
+	events := drainEvents(recorder)
+	if len(events) != 1 {
+		t.Fatalf("Expected only the template event by default, got %v", events)
+	}
+	if events[0] != "Normal JobTriggered Created job default/test-job" {
+		t.Errorf("Unexpected event: %s", events[0])
+	}
+
+	recordTriggerEvent(recorder, Options{InvolvedObjectEvents: true}, template, involved, corev1.EventTypeNormal,
+		reasonJobTriggered, "Created job %s", "default/test-job")
+
+	events = drainEvents(recorder)
+	if len(events) != 2 {
+		t.Fatalf("Expected events on the template and the involved object, got %v", events)
+	}
+	if !strings.Contains(events[1], "(template default/test-template)") {
+		t.Errorf("Expected involved object event to name the template, got %s", events[1])
+	}
+}
+
+func TestCheckTemplateValid(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+
+	if !checkTemplateValid(recorder, newEventsTestTemplate()) {
+		t.Errorf("Expected valid template to pass")
+	}
+
+	invalid := newEventsTestTemplate()
+	invalid.Spec.JobTemplate.Spec.Template.Spec.Containers = nil
+	if checkTemplateValid(recorder, invalid) {
+		t.Errorf("Expected template without containers to fail")
+	}
+
+	events := drainEvents(recorder)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Warning TemplateInvalid") {
+		t.Errorf("Expected a TemplateInvalid warning, got %v", events)
+	}
+}
+
+func TestEvaluateTemplateValidatesOnlyMatches(t *testing.T) {
+	controller := NewEventController(fake.NewSimpleClientset(), kubananafake.NewSimpleClientset())
+	recorder := record.NewFakeRecorder(10)
+	controller.recorder = recorder
+
+	invalid := newEventsTestTemplate()
+	invalid.Spec.JobTemplate.Spec.Template.Spec.Containers = nil
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web-1.123", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"},
+		Reason:         "Killing",
+	}
+	evaluate := func() bool {
+		return controller.evaluateTemplate(context.Background(), klog.Background(), invalid, event,
+			determineEventType(event), "Pod/default/web-1", func() triggerOrigin { return triggerOrigin{} })
+	}
+
+	// A DELETE event doesn't match the template, so it's not worth a warning
+	if evaluate() {
+		t.Fatalf("Expected the DELETE event not to match")
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("Expected no warning for an event that doesn't match, got %v", events)
+	}
+
+	event.Reason = "Created"
+	if evaluate() {
+		t.Fatalf("Expected the invalid template not to run")
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.HasPrefix(events[0], "Warning TemplateInvalid") {
+		t.Errorf("Expected a TemplateInvalid warning for a matching event, got %v", events)
+	}
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// workerHealth records the first worker goroutine of a controller that died
+type workerHealth struct {
+	mu  sync.Mutex
+	err error
+}
+
+// run runs a worker loop and records it as dead if it panics. The panic isn't propagated so
+// the health check can report it and let the kubelet restart the controller.
+func (h *workerHealth) run(name string, worker func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			klog.ErrorS(nil, "Worker died", "worker", name, "panic", r)
+
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.err == nil {
+				h.err = fmt.Errorf("worker %s died: %v", name, r)
+			}
+		}
+	}()
+
+	worker()
+}
+
+// check returns an error if a worker died
+func (h *workerHealth) check() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.err
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"testing"
+)
+
+func TestWorkerHealth(t *testing.T) {
+	var health workerHealth
+
+	health.run("healthy", func() {})
+	if err := health.check(); err != nil {
+		t.Fatalf("Expected healthy worker not to be reported, got %v", err)
+	}
+
+	health.run("panicking", func() { panic("boom") })
+	if err := health.check(); err == nil {
+		t.Fatalf("Expected dead worker to be reported")
+	}
+
+	// The first dead worker is kept
+	health.run("second", func() { panic("again") })
+	if err := health.check(); err == nil || err.Error() != "worker panicking died: boom" {
+		t.Errorf("Expected first dead worker to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// HistoryController periodically prunes finished jobs exceeding each template's history limits
+type HistoryController struct {
+	kubeClient     kubernetes.Interface
+	kubananaClient versioned.Interface
+	interval       time.Duration
+	namespaces     []string
+	options        Options
+}
+
+// NewHistoryController creates a new HistoryController that runs a cleanup every interval
+func NewHistoryController(
+	kubeClient kubernetes.Interface,
+	kubananaClient versioned.Interface,
+	interval time.Duration) *HistoryController {
+
+	return NewHistoryControllerWithOptions(kubeClient, kubananaClient, interval, Options{})
+}
+
+// NewHistoryControllerWithOptions creates a new HistoryController that runs a cleanup every interval
+// and only prunes jobs in the namespaces watched with the given options
+func NewHistoryControllerWithOptions(
+	kubeClient kubernetes.Interface,
+	kubananaClient versioned.Interface,
+	interval time.Duration,
+	options Options) *HistoryController {
+
+	return &HistoryController{
+		kubeClient:     kubeClient,
+		kubananaClient: kubananaClient,
+		interval:       interval,
+		namespaces:     watchNamespaces(options),
+		options:        options,
+	}
+}
+
+// Run starts the cleanup loop and blocks until stopCh is closed
+func (c *HistoryController) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting job history controller", "interval", c.interval)
+
+	wait.Until(func() {
+		if err := c.cleanup(); err != nil {
+			klog.ErrorS(err, "Job history cleanup incomplete")
+		}
+	}, c.interval, stopCh)
+
+	klog.InfoS("Shutting down job history controller")
+}
+
+// cleanup prunes the job history of every template that sets a history limit. A namespace or
+// template that fails doesn't stop the others from being pruned; their errors are aggregated.
+func (c *HistoryController) cleanup() error {
+	var errs []error
+	for _, namespace := range c.namespaces {
+		templateList, err := c.kubananaClient.KubananaV1alpha1().EventTriggeredJobs(namespace).
+			List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			klog.ErrorS(err, "Failed to fetch templates for history cleanup", "namespace", namespace)
+			errs = append(errs, fmt.Errorf("failed to list templates in namespace %s: %w", namespace, err))
+			continue
+		}
+
+		for i := range templateList.Items {
+			template := &templateList.Items[i]
+			if template.Spec.SuccessfulJobsHistoryLimit == nil && template.Spec.FailedJobsHistoryLimit == nil {
+				continue
+			}
+
+			logger := klog.LoggerWithValues(klog.Background(), logKeyTemplate, klog.KObj(template))
+			ctx := klog.NewContext(context.Background(), logger)
+			if err := pruneJobHistory(ctx, c.kubeClient, c.namespaces, template, c.options); err != nil {
+				logger.Error(err, "Failed to prune job history")
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// pruneJobHistory deletes the oldest finished jobs of a template beyond its history limits, only
+// server-side in a dry run
+func pruneJobHistory(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespaces []string,
+	template *v1alpha1.EventTriggeredJob,
+	options Options) error {
+
+	// Jobs may live in the triggering resource's namespace, so search all watched namespaces
+	var jobs []batchv1.Job
+	for _, namespace := range namespaces {
+		jobList, err := kubeClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.Set{options.labelKey(TemplateLabel): template.Name}.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list jobs for template %s: %w", template.Name, err)
+		}
+		jobs = append(jobs, jobList.Items...)
+	}
+
+	var succeeded, failed []batchv1.Job
+	for _, job := range jobs {
+		if !isJobOwnedBy(&job, template, options) {
+			continue
+		}
+
+		switch {
+		case isJobConditionTrue(&job, batchv1.JobComplete):
+			succeeded = append(succeeded, job)
+		case isJobConditionTrue(&job, batchv1.JobFailed):
+			failed = append(failed, job)
+		}
+	}
+
+	if limit := template.Spec.SuccessfulJobsHistoryLimit; limit != nil {
+		if err := deleteOldestJobs(ctx, kubeClient, succeeded, int(*limit), isDryRun(options, template)); err != nil {
+			return err
+		}
+	}
+
+	if limit := template.Spec.FailedJobsHistoryLimit; limit != nil {
+		if err := deleteOldestJobs(ctx, kubeClient, failed, int(*limit), isDryRun(options, template)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteOldestJobs deletes jobs, oldest first, until at most limit remain
+func deleteOldestJobs(ctx context.Context, kubeClient kubernetes.Interface, jobs []batchv1.Job, limit int, dryRun bool) error {
+	if len(jobs) <= limit {
+		return nil
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobFinishTime(&jobs[i]).Before(jobFinishTime(&jobs[j]))
+	})
+
+	propagation := metav1.DeletePropagationBackground
+	for _, job := range jobs[:len(jobs)-limit] {
+		err := kubeClient.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagation,
+			DryRun:            dryRunOption(dryRun),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+		if dryRun {
+			klog.FromContext(ctx).V(2).Info("Dry run: would have deleted job exceeding history limit", logKeyJob, klog.KObj(&job))
+			continue
+		}
+		klog.FromContext(ctx).V(2).Info("Deleted job exceeding history limit", logKeyJob, klog.KObj(&job))
+	}
+
+	return nil
+}
+
+// jobFinishTime returns the time a job finished, falling back to its creation time
+func jobFinishTime(job *batchv1.Job) time.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime.Time
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && !cond.LastTransitionTime.IsZero() {
+			return cond.LastTransitionTime.Time
+		}
+	}
+
+	return job.CreationTimestamp.Time
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newFinishedTestJob creates a finished job for the test template that completed at the given time
+func newFinishedTestJob(name string, conditionType batchv1.JobConditionType, finishedAt time.Time) *batchv1.Job {
+	job := newTestJob(name, "test-template", "pod-a", false)
+	finished := metav1.NewTime(finishedAt)
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:               conditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: finished,
+		},
+	}
+	if conditionType == batchv1.JobComplete {
+		job.Status.CompletionTime = &finished
+	}
+	return job
+}
+
+func TestPruneJobHistory(t *testing.T) {
+	now := time.Now()
+	successLimit := int32(1)
+	failedLimit := int32(0)
+
+	objects := []runtime.Object{
+		newFinishedTestJob("succeeded-old", batchv1.JobComplete, now.Add(-2*time.Hour)),
+		newFinishedTestJob("succeeded-new", batchv1.JobComplete, now.Add(-1*time.Hour)),
+		newFinishedTestJob("failed", batchv1.JobFailed, now.Add(-1*time.Hour)),
+		newTestJob("active", "test-template", "pod-a", false),
+		newTestJob("other-template", "other-template", "pod-a", true),
+	}
+	kubeClient := fake.NewSimpleClientset(objects...)
+
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template",
+			Namespace: "default",
+			UID:       types.UID("test-template-uid"),
+		},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			SuccessfulJobsHistoryLimit: &successLimit,
+			FailedJobsHistoryLimit:     &failedLimit,
+		},
+	}
+
+	if err := pruneJobHistory(context.Background(), kubeClient, []string{metav1.NamespaceAll}, template, Options{}); err != nil {
+		t.Fatalf("pruneJobHistory() returned error: %v", err)
+	}
+
+	jobs, err := kubeClient.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+
+	remaining := make(map[string]bool)
+	for _, job := range jobs.Items {
+		remaining[job.Name] = true
+	}
+
+	for _, name := range []string{"succeeded-new", "active", "other-template"} {
+		if !remaining[name] {
+			t.Errorf("Expected job %s to be kept", name)
+		}
+	}
+
+	for _, name := range []string{"succeeded-old", "failed"} {
+		if remaining[name] {
+			t.Errorf("Expected job %s to be pruned", name)
+		}
+	}
+}
+
+func TestPruneJobHistoryWithoutLimits(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		newFinishedTestJob("succeeded", batchv1.JobComplete, time.Now()),
+		newFinishedTestJob("failed", batchv1.JobFailed, time.Now()),
+	)
+
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template",
+			Namespace: "default",
+			UID:       types.UID("test-template-uid"),
+		},
+	}
+
+	if err := pruneJobHistory(context.Background(), kubeClient, []string{metav1.NamespaceAll}, template, Options{}); err != nil {
+		t.Fatalf("pruneJobHistory() returned error: %v", err)
+	}
+
+	jobs, err := kubeClient.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+
+	if len(jobs.Items) != 2 {
+		t.Errorf("Expected 2 jobs to be kept, got %d", len(jobs.Items))
+	}
+}
+
+func TestApplyJobDefaults(t *testing.T) {
+	defaultTTL := int32(3600)
+	templateTTL := int32(60)
+
+	job := &batchv1.Job{}
+	applyJobDefaults(job, Options{DefaultJobTTLSeconds: &defaultTTL})
+	if job.Spec.TTLSecondsAfterFinished == nil || *job.Spec.TTLSecondsAfterFinished != defaultTTL {
+		t.Errorf("Expected default TTL %d to be applied", defaultTTL)
+	}
+
+	job = &batchv1.Job{Spec: batchv1.JobSpec{TTLSecondsAfterFinished: &templateTTL}}
+	applyJobDefaults(job, Options{DefaultJobTTLSeconds: &defaultTTL})
+	if *job.Spec.TTLSecondsAfterFinished != templateTTL {
+		t.Errorf("Expected template TTL %d to be kept, got %d", templateTTL, *job.Spec.TTLSecondsAfterFinished)
+	}
+
+	job = &batchv1.Job{}
+	applyJobDefaults(job, Options{})
+	if job.Spec.TTLSecondsAfterFinished != nil {
+		t.Errorf("Expected no TTL when the default is disabled")
+	}
+
+	defaultBackoffLimit := int32(6)
+	templateBackoffLimit := int32(0)
+
+	job = &batchv1.Job{}
+	applyJobDefaults(job, Options{DefaultJobBackoffLimit: &defaultBackoffLimit})
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != defaultBackoffLimit {
+		t.Errorf("Expected default backoffLimit %d to be applied", defaultBackoffLimit)
+	}
+
+	job = &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: &templateBackoffLimit}}
+	applyJobDefaults(job, Options{DefaultJobBackoffLimit: &defaultBackoffLimit})
+	if *job.Spec.BackoffLimit != templateBackoffLimit {
+		t.Errorf("Expected template backoffLimit %d to be kept, got %d", templateBackoffLimit, *job.Spec.BackoffLimit)
+	}
+}
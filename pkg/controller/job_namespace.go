@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Default job namespace policies of templates that don't set one. Event-triggered jobs have always
+// run next to the event's object, status-triggered jobs next to the template.
+const (
+	eventJobNamespacePolicy  = v1alpha1.ResourceJobNamespacePolicy
+	statusJobNamespacePolicy = v1alpha1.TemplateJobNamespacePolicy
+)
+
+// jobNamespace returns the namespace of jobs the template creates for a resource in resourceNamespace.
+// Templates without a JobNamespacePolicy use defaultPolicy, which depends on the trigger source, unless
+// they set a service account: it only exists in the template's namespace, so their jobs run there.
+func jobNamespace(template *v1alpha1.EventTriggeredJob, resourceNamespace string, defaultPolicy v1alpha1.JobNamespacePolicy) string {
+	policy := template.Spec.JobNamespacePolicy
+	if policy == "" && template.Spec.ServiceAccountName != "" {
+		policy = v1alpha1.TemplateJobNamespacePolicy
+	}
+	if policy == "" {
+		policy = defaultPolicy
+	}
+
+	if policy == v1alpha1.ResourceJobNamespacePolicy && resourceNamespace != "" {
+		return resourceNamespace
+	}
+
+	return template.Namespace
+}
+
+// setJobOwner links the job to the template that created it. Owner references can't cross
+// namespaces, so jobs outside the template's namespace only carry the template labels.
+func setJobOwner(job *batchv1.Job, template *v1alpha1.EventTriggeredJob) {
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	job.Labels[TemplateLabel] = template.Name
+	job.Labels[TemplateNamespaceLabel] = template.Namespace
+
+	if job.Namespace != template.Namespace {
+		return
+	}
+
+	job.OwnerReferences = append(job.OwnerReferences, metav1.OwnerReference{
+		APIVersion: "kubanana.roshanbhatia.com/v1alpha1",
+		Kind:       "EventTriggeredJob",
+		Name:       template.Name,
+		UID:        template.UID,
+		Controller: &[]bool{true}[0],
+	})
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestJobNamespace(t *testing.T) {
+	tests := []struct {
+		name              string
+		policy            v1alpha1.JobNamespacePolicy
+		defaultPolicy     v1alpha1.JobNamespacePolicy
+		serviceAccount    string
+		resourceNamespace string
+		expected          string
+	}{
+		{name: "event default", defaultPolicy: eventJobNamespacePolicy, resourceNamespace: "prod", expected: "prod"},
+		{name: "status default", defaultPolicy: statusJobNamespacePolicy, resourceNamespace: "prod", expected: "kubanana-system"},
+		{name: "template", policy: v1alpha1.TemplateJobNamespacePolicy, defaultPolicy: eventJobNamespacePolicy, resourceNamespace: "prod", expected: "kubanana-system"},
+		{name: "resource", policy: v1alpha1.ResourceJobNamespacePolicy, defaultPolicy: statusJobNamespacePolicy, resourceNamespace: "prod", expected: "prod"},
+		{name: "cluster-scoped resource", policy: v1alpha1.ResourceJobNamespacePolicy, expected: "kubanana-system"},
+		{name: "service account", serviceAccount: "deployer", defaultPolicy: eventJobNamespacePolicy, resourceNamespace: "prod", expected: "kubanana-system"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &v1alpha1.EventTriggeredJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "kubanana-system"},
+				Spec:       v1alpha1.EventTriggeredJobSpec{JobNamespacePolicy: tt.policy, ServiceAccountName: tt.serviceAccount},
+			}
+
+			if namespace := jobNamespace(template, tt.resourceNamespace, tt.defaultPolicy); namespace != tt.expected {
+				t.Errorf("jobNamespace() = %q, want %q", namespace, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewEventJobDefaultsToResourceNamespace(t *testing.T) {
+	// Templates without a policy keep creating event-triggered jobs next to the event's object
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "kubanana-system"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "busybox"}}},
+					},
+				},
+			},
+		},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-event", Namespace: "prod"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "prod"},
+	}
+
+	if job := newEventJob(template, event, "CREATE", triggerOrigin{}, nil, Options{}); job.Namespace != "prod" {
+		t.Errorf("Expected the job in the event's namespace, got %q", job.Namespace)
+	}
+
+	template.Spec.JobNamespacePolicy = v1alpha1.TemplateJobNamespacePolicy
+	if job := newEventJob(template, event, "CREATE", triggerOrigin{}, nil, Options{}); job.Namespace != "kubanana-system" {
+		t.Errorf("Expected the Template policy to create the job in the template's namespace, got %q", job.Namespace)
+	}
+}
+
+func TestSetJobOwner(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "kubanana-system", UID: types.UID("template-uid")},
+	}
+
+	sameNamespace := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "kubanana-system"}}
+	setJobOwner(sameNamespace, template)
+	if len(sameNamespace.OwnerReferences) != 1 || sameNamespace.OwnerReferences[0].UID != template.UID {
+		t.Errorf("Expected job in the template's namespace to be owned by it, got %v", sameNamespace.OwnerReferences)
+	}
+	if !isJobOwnedBy(sameNamespace, template, Options{}) {
+		t.Error("Expected isJobOwnedBy to match the owned job")
+	}
+
+	otherNamespace := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "prod"}}
+	setJobOwner(otherNamespace, template)
+	if len(otherNamespace.OwnerReferences) != 0 {
+		t.Errorf("Expected no owner reference across namespaces, got %v", otherNamespace.OwnerReferences)
+	}
+	if otherNamespace.Labels[TemplateLabel] != "test-template" || otherNamespace.Labels[TemplateNamespaceLabel] != "kubanana-system" {
+		t.Errorf("Expected template labels on the job, got %v", otherNamespace.Labels)
+	}
+	if !isJobOwnedBy(otherNamespace, template, Options{}) {
+		t.Error("Expected isJobOwnedBy to match the labeled job in another namespace")
+	}
+
+	otherTemplate := template.DeepCopy()
+	otherTemplate.Namespace = "other"
+	otherTemplate.UID = types.UID("other-uid")
+	if isJobOwnedBy(otherNamespace, otherTemplate, Options{}) {
+		t.Error("Expected isJobOwnedBy not to match a template with the same name in another namespace")
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Labels set on every job, so clients can find the jobs of a template and what triggered them
+const (
+	// TemplateLabel is the name of the template that created the job
+	TemplateLabel = "kubanana-template"
+
+	// TemplateNamespaceLabel is the namespace of the template that created the job
+	TemplateNamespaceLabel = "kubanana-template-namespace"
+
+	// ResourceKindLabel is the kind of the resource that triggered the job
+	ResourceKindLabel = "kubanana-resource-kind"
+
+	// ResourceNamespaceLabel is the namespace of the resource that triggered the job
+	ResourceNamespaceLabel = "kubanana-resource-namespace"
+
+	// ResourceNameLabel is the name of the resource that triggered the job
+	ResourceNameLabel = "kubanana-resource-name"
+
+	// EventTypeLabel is the event type (CREATE, UPDATE or DELETE) of event-triggered jobs
+	EventTypeLabel = "kubanana-event-type"
+
+	// TriggerTypeLabel is "status" on jobs triggered by a status selector
+	TriggerTypeLabel = "kubanana-trigger-type"
+)
+
+const (
+	// DefaultLabelPrefix is the prefix of the job labels, replaced by Options.LabelPrefix
+	DefaultLabelPrefix = "kubanana-"
+
+	// DefaultAnnotationPrefix is the prefix of the job and pod annotations, replaced by Options.AnnotationPrefix
+	DefaultAnnotationPrefix = "kubanana.roshanbhatia.com/"
+)
+
+// jobLabels are the labels set on created jobs and their pods
+var jobLabels = []string{
+	TemplateLabel, TemplateNamespaceLabel, ResourceKindLabel, ResourceNamespaceLabel, ResourceNameLabel, EventTypeLabel,
+	TriggerTypeLabel,
+}
+
+// jobAnnotations are the annotations set on created jobs and their pods
+var jobAnnotations = []string{triggerDepthAnnotation, maxTriggerDepthAnnotation, traceParentAnnotation}
+
+// labelKey returns the key of a job label with the configured prefix
+func (o Options) labelKey(key string) string {
+	if o.LabelPrefix == "" {
+		return key
+	}
+	return o.LabelPrefix + strings.TrimPrefix(key, DefaultLabelPrefix)
+}
+
+// annotationKey returns the key of a job annotation with the configured prefix
+func (o Options) annotationKey(key string) string {
+	if o.AnnotationPrefix == "" {
+		return key
+	}
+	return o.AnnotationPrefix + strings.TrimPrefix(key, DefaultAnnotationPrefix)
+}
+
+// applyKeyPrefixes moves the labels and annotations Kubanana set on the job and its pods to the
+// configured prefixes
+func applyKeyPrefixes(job *batchv1.Job, options Options) {
+	for _, objMeta := range []*metav1.ObjectMeta{&job.ObjectMeta, &job.Spec.Template.ObjectMeta} {
+		objMeta.Labels = renameKeys(objMeta.Labels, jobLabels, options.labelKey)
+		objMeta.Annotations = renameKeys(objMeta.Annotations, jobAnnotations, options.annotationKey)
+	}
+}
+
+// renameKeys returns m with each of keys that it contains renamed by rename
+func renameKeys(m map[string]string, keys []string, rename func(string) string) map[string]string {
+	var renamed map[string]string
+	for _, key := range keys {
+		value, ok := m[key]
+		if !ok || rename(key) == key {
+			continue
+		}
+
+		// The maps may still be shared with the template, so they're copied before changing them
+		if renamed == nil {
+			renamed = copyStringMap(m)
+		}
+		delete(renamed, key)
+		renamed[rename(key)] = value
+	}
+
+	if renamed == nil {
+		return m
+	}
+	return renamed
+}
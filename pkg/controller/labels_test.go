@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyPrefixes(t *testing.T) {
+	options := Options{LabelPrefix: "example.com/", AnnotationPrefix: "example.com/kubanana-"}
+
+	if key := options.labelKey(TemplateLabel); key != "example.com/template" {
+		t.Errorf("Expected the label prefix to be replaced, got %s", key)
+	}
+	if key := options.annotationKey(triggerDepthAnnotation); key != "example.com/kubanana-trigger-depth" {
+		t.Errorf("Expected the annotation prefix to be replaced, got %s", key)
+	}
+	if key := (Options{}).labelKey(TemplateLabel); key != TemplateLabel {
+		t.Errorf("Expected the default label key without a prefix, got %s", key)
+	}
+}
+
+func TestApplyKeyPrefixes(t *testing.T) {
+	// The pod template's maps are shared with the template the job was created from
+	podLabels := map[string]string{TemplateLabel: "test-template", "app": "web"}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{TemplateLabel: "test-template", ResourceKindLabel: "Pod"},
+			Annotations: map[string]string{triggerDepthAnnotation: "1"},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+
+	applyKeyPrefixes(job, Options{LabelPrefix: "example.com/", AnnotationPrefix: "example.com/kubanana-"})
+
+	if job.Labels["example.com/template"] != "test-template" || job.Labels["example.com/resource-kind"] != "Pod" {
+		t.Errorf("Expected the job labels to be renamed, got %v", job.Labels)
+	}
+	if _, ok := job.Labels[TemplateLabel]; ok {
+		t.Errorf("Expected the default label to be removed, got %v", job.Labels)
+	}
+	if job.Annotations["example.com/kubanana-trigger-depth"] != "1" {
+		t.Errorf("Expected the job annotations to be renamed, got %v", job.Annotations)
+	}
+
+	podTemplate := job.Spec.Template
+	if podTemplate.Labels["example.com/template"] != "test-template" || podTemplate.Labels["app"] != "web" {
+		t.Errorf("Expected the pod labels to be renamed and kept, got %v", podTemplate.Labels)
+	}
+	if _, ok := podLabels["example.com/template"]; ok {
+		t.Errorf("Expected the template's labels not to be changed, got %v", podLabels)
+	}
+}
+
+func TestApplyDefaultKeyPrefixes(t *testing.T) {
+	labels := map[string]string{TemplateLabel: "test-template"}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+
+	applyKeyPrefixes(job, Options{LabelPrefix: DefaultLabelPrefix, AnnotationPrefix: DefaultAnnotationPrefix})
+
+	if job.Labels[TemplateLabel] != "test-template" || len(job.Labels) != 1 {
+		t.Errorf("Expected the default keys to be kept, got %v", job.Labels)
+	}
+}
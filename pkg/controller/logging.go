@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// Keys of the structured logs, shared by both controllers
+const (
+	logKeyTemplate = "template"
+	logKeyTrigger  = "trigger"
+	logKeyResource = "resource"
+	logKeyJob      = "job"
+	logKeyTraceID  = "traceID"
+)
+
+// traceIDKey is the context key of the ID that ties together the logs and audit records of a trigger
+type traceIDKey struct{}
+
+// withTrigger returns a context for handling a trigger of template about resource. Its logger carries the
+// template, trigger type, resource and the ID of the trace in ctx, or a new one if the trigger isn't traced.
+func withTrigger(ctx context.Context, template *v1alpha1.EventTriggeredJob, trigger, resource string) context.Context {
+	traceID := newTraceID()
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		traceID = spanContext.TraceID().String()
+	}
+	logger := klog.FromContext(ctx).WithValues(
+		logKeyTemplate, klog.KObj(template),
+		logKeyTrigger, trigger,
+		logKeyResource, resource,
+		logKeyTraceID, traceID)
+
+	return klog.NewContext(context.WithValue(ctx, traceIDKey{}, traceID), logger)
+}
+
+// traceIDFromContext returns the trace ID of the trigger handled with ctx, or an empty string
+func traceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// newTraceID returns a random ID in the format of W3C trace IDs
+func newTraceID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// triggerResource identifies the resource of a trigger in logs, rate limits and debounce batches
+func triggerResource(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
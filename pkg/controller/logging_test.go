@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+func TestWithTrigger(t *testing.T) {
+	var lines []string
+	logger := funcr.NewJSON(func(obj string) { lines = append(lines, obj) }, funcr.Options{})
+	template := &v1alpha1.EventTriggeredJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-template"}}
+
+	ctx := withTrigger(klog.NewContext(context.Background(), logger), template, eventTrigger,
+		triggerResource("Pod", "default", "web-1"))
+	klog.FromContext(ctx).Info("Created job", logKeyJob, klog.KRef("default", "test-template-abc"))
+
+	traceID := traceIDFromContext(ctx)
+	if len(traceID) != 32 {
+		t.Fatalf("Expected a 32 character trace ID, got %q", traceID)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected one log line, got %v", lines)
+	}
+	for _, field := range []string{
+		`"template":{"name":"test-template","namespace":"default"}`,
+		`"trigger":"event"`,
+		`"resource":"Pod/default/web-1"`,
+		`"job":{"name":"test-template-abc","namespace":"default"}`,
+		`"traceID":"` + traceID + `"`,
+	} {
+		if !strings.Contains(lines[0], field) {
+			t.Errorf("Expected %s in log line %s", field, lines[0])
+		}
+	}
+
+	if other := withTrigger(context.Background(), template, eventTrigger, "Pod/default/web-2"); traceIDFromContext(other) == traceID {
+		t.Errorf("Expected every trigger to get its own trace ID")
+	}
+}
+
+func TestTraceIDFromContextWithoutTrigger(t *testing.T) {
+	if traceID := traceIDFromContext(context.Background()); traceID != "" {
+		t.Errorf("Expected no trace ID outside of a trigger, got %q", traceID)
+	}
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ManualTriggerAnnotation marks jobs that were triggered by hand instead of by the controllers
+const ManualTriggerAnnotation = "kubanana.roshanbhatia.com/manual-trigger"
+
+// NewManualJob renders the job template runs for a resource as if the resource had triggered it. Event-based
+// templates get an event of eventType, or their first event type if it is empty, and status-based templates get
+// the conditions of their selector. The job gets the defaults of options, like the jobs the controllers create.
+func NewManualJob(
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name, eventType string,
+	options Options) (*batchv1.Job, error) {
+
+	var job *batchv1.Job
+	if template.Spec.EventSelector != nil {
+		if eventType == "" && len(template.Spec.EventSelector.EventTypes) > 0 {
+			eventType = template.Spec.EventSelector.EventTypes[0]
+		}
+		if eventType == "" {
+			eventType = "CREATE"
+		}
+
+		event := &corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: resourceKind, Namespace: namespace, Name: name},
+		}
+		job = newEventJob(template, event, eventType, triggerOrigin{}, nil, options)
+	} else {
+		conditions := map[string]string{}
+		if template.Spec.StatusSelector != nil {
+			for _, condition := range template.Spec.StatusSelector.Conditions {
+				conditions[condition.Type] = condition.Status
+			}
+		}
+		job = newStatusJob(template, resourceKind, namespace, name, conditions, triggerOrigin{}, nil, options)
+	}
+
+	if err := checkJobServiceAccount(job, template); err != nil {
+		return nil, err
+	}
+
+	job.Annotations = copyStringMap(job.Annotations)
+	job.Annotations[ManualTriggerAnnotation] = "true"
+
+	return job, nil
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewManualJobForEventTemplate(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	template.Spec.EventSelector = &v1alpha1.EventSelector{ResourceKind: "Pod", EventTypes: []string{"DELETE"}}
+	template.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "test", Image: "busybox", Command: []string{"echo", "$RESOURCE_NAME $EVENT_TYPE"}},
+	}
+
+	job, err := NewManualJob(template, "Pod", "default", "web-1", "", Options{})
+	if err != nil {
+		t.Fatalf("Failed to render job: %v", err)
+	}
+
+	if job.Labels[EventTypeLabel] != "DELETE" || job.Labels[ResourceNameLabel] != "web-1" {
+		t.Errorf("Expected the template's first event type and the resource in the labels, got %v", job.Labels)
+	}
+	if job.Annotations[ManualTriggerAnnotation] != "true" {
+		t.Errorf("Expected the job to be marked as triggered by hand, got %v", job.Annotations)
+	}
+	if command := job.Spec.Template.Spec.Containers[0].Command[1]; command != "web-1 DELETE" {
+		t.Errorf("Expected the variables to be substituted, got %q", command)
+	}
+	if command := template.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Command[1]; command != "$RESOURCE_NAME $EVENT_TYPE" {
+		t.Errorf("Expected the template to be left alone, got %q", command)
+	}
+}
+
+func TestNewManualJobForStatusTemplate(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	template.Spec.StatusSelector = &v1alpha1.StatusSelector{
+		ResourceKind: "Pod",
+		Conditions:   []v1alpha1.StatusCondition{{Type: "Ready", Status: "True"}},
+	}
+
+	job, err := NewManualJob(template, "Pod", "default", "web-1", "", Options{})
+	if err != nil {
+		t.Fatalf("Failed to render job: %v", err)
+	}
+
+	if job.Labels[TriggerTypeLabel] != "status" || job.Labels["condition-Ready"] != "True" {
+		t.Errorf("Expected a status job for the selector's conditions, got labels %v", job.Labels)
+	}
+}
+
+func TestNewManualJobRejectsOtherServiceAccounts(t *testing.T) {
+	template := newServiceAccountTestTemplate("deployer")
+	template.Spec.EventSelector = &v1alpha1.EventSelector{ResourceKind: "Pod", EventTypes: []string{"CREATE"}}
+	template.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = "admin"
+
+	if _, err := NewManualJob(template, "Pod", "default", "web-1", "", Options{}); err == nil {
+		t.Errorf("Expected pods running as another service account to be rejected")
+	}
+}
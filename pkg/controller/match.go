@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FieldMatch is the outcome of matching one selector field of a template
+type FieldMatch struct {
+	// Field is the path of the selector field, e.g. spec.eventSelector.namePattern
+	Field string
+
+	// Matched is set if the field doesn't keep the template from running
+	Matched bool
+
+	// Reason explains why the field matched or not
+	Reason string
+}
+
+// MatchResult lists the selector fields of a template in the order the controllers check them
+type MatchResult struct {
+	Fields []FieldMatch
+}
+
+// Matched checks if all fields matched
+func (r MatchResult) Matched() bool {
+	for _, field := range r.Fields {
+		if !field.Matched {
+			return false
+		}
+	}
+
+	return len(r.Fields) > 0
+}
+
+// Reason explains why the first field that didn't match failed
+func (r MatchResult) Reason() string {
+	for _, field := range r.Fields {
+		if !field.Matched {
+			return field.Field + ": " + field.Reason
+		}
+	}
+
+	return ""
+}
+
+// add records the outcome of matching field
+func (r *MatchResult) add(field string, matched bool, format string, args ...interface{}) {
+	r.Fields = append(r.Fields, FieldMatch{Field: field, Matched: matched, Reason: fmt.Sprintf(format, args...)})
+}
+
+// MatchEvent matches the event selector of template against event. Runtime checks such as namespace
+// access, self triggers and rate limits aren't part of it.
+func MatchEvent(template *v1alpha1.EventTriggeredJob, event *corev1.Event) MatchResult {
+	result := MatchResult{}
+
+	selector := template.Spec.EventSelector
+	if selector == nil {
+		result.add("spec.eventSelector", false, "template has no eventSelector")
+		return result
+	}
+	ref := event.InvolvedObject
+
+	result.add("spec.eventSelector.resourceKind", selector.ResourceKind == ref.Kind,
+		"event is about a %s, template watches %s", ref.Kind, selector.ResourceKind)
+
+	eventType := determineEventType(event)
+	switch {
+	case eventType == "":
+		result.add("spec.eventSelector.eventTypes", false, "event type of reason %q can't be determined", event.Reason)
+	case containsEventType(selector.EventTypes, eventType):
+		result.add("spec.eventSelector.eventTypes", true, "reason %q is a %s event", event.Reason, eventType)
+	default:
+		result.add("spec.eventSelector.eventTypes", false, "reason %q is a %s event, template wants %s",
+			event.Reason, eventType, strings.Join(selector.EventTypes, ", "))
+	}
+
+	matchPattern(&result, "spec.eventSelector.namePattern", selector.NamePattern, "name", ref.Name)
+	matchPattern(&result, "spec.eventSelector.namespacePattern", selector.NamespacePattern, "namespace", ref.Namespace)
+
+	if selector.LabelSelector != nil {
+		result.add("spec.eventSelector.labelSelector", true, "label selectors aren't matched yet")
+	}
+
+	return result
+}
+
+// MatchStatus matches the status selector of template against a resource with the given status conditions.
+// Runtime checks such as namespace access, self triggers and rate limits aren't part of it.
+func MatchStatus(
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	conditions map[string]string) MatchResult {
+
+	result := MatchResult{}
+
+	selector := template.Spec.StatusSelector
+	if selector == nil {
+		result.add("spec.statusSelector", false, "template has no statusSelector")
+		return result
+	}
+
+	result.add("spec.statusSelector.resourceKind", selector.ResourceKind == resourceKind,
+		"resource is a %s, template watches %s", resourceKind, selector.ResourceKind)
+
+	matchPattern(&result, "spec.statusSelector.namePattern", selector.NamePattern, "name", name)
+	matchPattern(&result, "spec.statusSelector.namespacePattern", selector.NamespacePattern, "namespace", namespace)
+
+	if selector.LabelSelector != nil {
+		result.add("spec.statusSelector.labelSelector", true, "label selectors aren't matched yet")
+	}
+
+	for i, required := range selector.Conditions {
+		field := fmt.Sprintf("spec.statusSelector.conditions[%d]", i)
+		actual, exists := conditions[required.Type]
+		switch {
+		case !exists:
+			result.add(field, false, "resource has no %s condition", required.Type)
+		case actual != required.Status:
+			result.add(field, false, "%s is %s, template wants %s", required.Type, actual, required.Status)
+		default:
+			result.add(field, true, "%s is %s", required.Type, actual)
+		}
+	}
+
+	return result
+}
+
+// matchPattern records whether pattern matches value. Empty patterns match everything.
+func matchPattern(result *MatchResult, field, pattern, what, value string) {
+	if pattern == "" {
+		result.add(field, true, "no pattern, any %s matches", what)
+		return
+	}
+
+	matched := matchNamePattern(pattern, value)
+	if matched {
+		result.add(field, true, "%s %q matches %q", what, value, pattern)
+	} else {
+		result.add(field, false, "%s %q doesn't match %q", what, value, pattern)
+	}
+}
+
+// containsEventType checks if eventType is one of eventTypes
+func containsEventType(eventTypes []string, eventType string) bool {
+	for _, allowed := range eventTypes {
+		if allowed == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusConditions extracts the type and status of the status conditions of an unstructured object
+func StatusConditions(obj map[string]interface{}) map[string]string {
+	conditionMap := make(map[string]string)
+
+	conditions, found, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !found {
+		return conditionMap
+	}
+
+	// Different resources may store conditions differently, only type and status are needed
+	for _, cond := range conditions {
+		condition, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condType, typeFound := condition["type"].(string)
+		condStatus, statusFound := condition["status"].(string)
+
+		if typeFound && statusFound {
+			conditionMap[condType] = condStatus
+		}
+	}
+
+	return conditionMap
+}
+
+// conditionsChangedSince checks if a condition of the object transitioned at or after since, to
+// the second the transition times are stored with. Conditions without a lastTransitionTime are ignored.
+func conditionsChangedSince(obj map[string]interface{}, since time.Time) bool {
+	conditions, found, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !found {
+		return false
+	}
+
+	since = since.Truncate(time.Second)
+	for _, cond := range conditions {
+		condition, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		transitioned, ok := condition["lastTransitionTime"].(string)
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, transitioned); err == nil && !t.Before(since) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusChanged checks if the status conditions of a resource changed, which is when the status
+// controller evaluates templates
+func StatusChanged(old, new map[string]string) bool {
+	return !statusEqual(old, new)
+}
+
+// RenderEventJob renders the job template creates for event, the way the event controller does without
+// controller-wide defaults
+func RenderEventJob(template *v1alpha1.EventTriggeredJob, event *corev1.Event) (*batchv1.Job, error) {
+	job := newEventJob(template, event, determineEventType(event), triggerOrigin{}, nil, Options{})
+	if err := checkJobServiceAccount(job, template); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RenderStatusJob renders the job template creates for a resource whose status conditions matched, the
+// way the status controller does without controller-wide defaults
+func RenderStatusJob(
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	conditions map[string]string) (*batchv1.Job, error) {
+
+	job := newStatusJob(template, resourceKind, namespace, name, conditions, triggerOrigin{}, nil, Options{})
+	if err := checkJobServiceAccount(job, template); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// conditionTypes lists the condition types of conditions in a stable order
+func conditionTypes(conditions map[string]string) []string {
+	types := make([]string, 0, len(conditions))
+	for condType := range conditions {
+		types = append(types, condType)
+	}
+	sort.Strings(types)
+
+	return types
+}
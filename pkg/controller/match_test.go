@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newMatchTestEvent creates a Created event about the pod namespace/name
+func newMatchTestEvent(namespace, name string) *corev1.Event {
+	return &corev1.Event{
+		Reason:         "Created",
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: name},
+	}
+}
+
+func TestMatchEvent(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	template.Spec.EventSelector = &v1alpha1.EventSelector{
+		ResourceKind:     "Pod",
+		NamePattern:      "web-*",
+		NamespacePattern: "prod-*",
+		EventTypes:       []string{"CREATE"},
+	}
+
+	if result := MatchEvent(template, newMatchTestEvent("prod-east", "web-1")); !result.Matched() {
+		t.Errorf("Expected the event to match, got %s", result.Reason())
+	}
+
+	result := MatchEvent(template, newMatchTestEvent("dev", "web-1"))
+	if result.Matched() {
+		t.Fatalf("Expected an event of another namespace not to match")
+	}
+	if len(result.Fields) != 4 {
+		t.Errorf("Expected all four selector fields to be reported, got %v", result.Fields)
+	}
+	if reason := result.Reason(); reason != `spec.eventSelector.namespacePattern: namespace "dev" doesn't match "prod-*"` {
+		t.Errorf("Expected the namespace pattern to be the reason, got %q", reason)
+	}
+
+	deleted := newMatchTestEvent("prod-east", "web-1")
+	deleted.Reason = "Killing"
+	if result := MatchEvent(template, deleted); result.Matched() || result.Fields[1].Matched {
+		t.Errorf("Expected a DELETE event not to match the event types, got %v", result.Fields)
+	}
+}
+
+func TestMatchEventWithoutEventSelector(t *testing.T) {
+	if result := MatchEvent(newServiceAccountTestTemplate(""), newMatchTestEvent("default", "web-1")); result.Matched() {
+		t.Errorf("Expected a template without an eventSelector not to match")
+	}
+}
+
+func TestMatchStatus(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	template.Spec.StatusSelector = &v1alpha1.StatusSelector{
+		ResourceKind: "Pod",
+		Conditions: []v1alpha1.StatusCondition{
+			{Type: "Ready", Status: "True"},
+			{Type: "Initialized", Status: "True"},
+		},
+	}
+
+	conditions := map[string]string{"Ready": "True", "Initialized": "True"}
+	if result := MatchStatus(template, "Pod", "default", "web-1", conditions); !result.Matched() {
+		t.Errorf("Expected the status to match, got %s", result.Reason())
+	}
+
+	result := MatchStatus(template, "Pod", "default", "web-1", map[string]string{"Ready": "False"})
+	if result.Matched() {
+		t.Fatalf("Expected unready pods not to match")
+	}
+	if reason := result.Reason(); reason != "spec.statusSelector.conditions[0]: Ready is False, template wants True" {
+		t.Errorf("Expected the Ready condition to be the reason, got %q", reason)
+	}
+	if last := result.Fields[len(result.Fields)-1]; last.Matched || last.Reason != "resource has no Initialized condition" {
+		t.Errorf("Expected the missing Initialized condition to be reported, got %+v", last)
+	}
+}
+
+func TestStatusConditions(t *testing.T) {
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Broken"},
+				"garbage",
+			},
+		},
+	}
+
+	conditions := StatusConditions(obj)
+	if len(conditions) != 1 || conditions["Ready"] != "True" {
+		t.Errorf("Expected only the Ready condition, got %v", conditions)
+	}
+	if len(StatusConditions(map[string]interface{}{})) != 0 {
+		t.Errorf("Expected no conditions without a status")
+	}
+	if StatusChanged(conditions, map[string]string{"Ready": "True"}) {
+		t.Errorf("Expected equal conditions not to be a change")
+	}
+}
+
+func TestRenderStatusJobOrdersConditionEnv(t *testing.T) {
+	template := newServiceAccountTestTemplate("")
+	template.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Name: "test", Image: "busybox"}}
+
+	job, err := RenderStatusJob(template, "Pod", "default", "web-1",
+		map[string]string{"Ready": "True", "Initialized": "True", "ContainersReady": "True"})
+	if err != nil {
+		t.Fatalf("Failed to render job: %v", err)
+	}
+
+	var names []string
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		names = append(names, env.Name)
+	}
+	expected := []string{"RESOURCE_KIND", "RESOURCE_NAME", "RESOURCE_NAMESPACE", "TRIGGER_TYPE",
+		"STATUS_ContainersReady", "STATUS_Initialized", "STATUS_Ready"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected env %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected env %v, got %v", expected, names)
+			break
+		}
+	}
+}
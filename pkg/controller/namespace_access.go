@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespaceAccess decides which namespaces templates may observe. Templates only see their own namespace,
+// unless it carries the validation.CrossNamespaceLabel.
+type namespaceAccess struct {
+	informer *namespacedInformer
+}
+
+// newNamespaceAccess caches the watched namespaces, only reading each of them if the namespaces are restricted
+func newNamespaceAccess(kubeClient kubernetes.Interface, namespaces []string) *namespaceAccess {
+	informer := newNamespacedInformer(namespaces, func(namespace string) cache.SharedIndexInformer {
+		tweak := func(options *metav1.ListOptions) {
+			if namespace != metav1.NamespaceAll {
+				options.FieldSelector = fields.OneTermEqualSelector("metadata.name", namespace).String()
+			}
+		}
+
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					tweak(&options)
+					return kubeClient.CoreV1().Namespaces().List(context.Background(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					tweak(&options)
+					return kubeClient.CoreV1().Namespaces().Watch(context.Background(), options)
+				},
+			},
+			&corev1.Namespace{},
+			0,
+			cache.Indexers{},
+		)
+	})
+
+	return &namespaceAccess{informer: informer}
+}
+
+// canObserve checks if template may trigger on resources of namespace
+func (a *namespaceAccess) canObserve(template *v1alpha1.EventTriggeredJob, namespace string) bool {
+	if namespace == template.Namespace && namespace != metav1.NamespaceNone {
+		return true
+	}
+
+	return validation.CrossNamespaceAllowed(a.labels(template.Namespace))
+}
+
+// labels returns the cached labels of namespace, nil if it isn't cached
+func (a *namespaceAccess) labels(namespace string) map[string]string {
+	informer, ok := a.informer.informers[namespace]
+	if !ok {
+		informer, ok = a.informer.informers[metav1.NamespaceAll]
+	}
+	if !ok {
+		return nil
+	}
+
+	item, exists, err := informer.GetStore().GetByKey(namespace)
+	if err != nil || !exists {
+		return nil
+	}
+
+	return item.(*corev1.Namespace).Labels
+}
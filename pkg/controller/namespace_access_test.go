@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNamespaceAccessCanObserve(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "platform",
+			Labels: map[string]string{validation.CrossNamespaceLabel: "true"},
+		}},
+	)
+
+	tests := []struct {
+		name       string
+		namespaces []string
+		template   string
+		namespace  string
+		canObserve bool
+	}{
+		{name: "own namespace", template: "team-a", namespace: "team-a", canObserve: true},
+		{name: "other namespace", template: "team-a", namespace: "team-b"},
+		{name: "cluster-scoped resource", template: "team-a", namespace: metav1.NamespaceNone},
+		{name: "granted namespace", template: "platform", namespace: "team-b", canObserve: true},
+		{name: "granted cluster-scoped resource", template: "platform", namespace: metav1.NamespaceNone, canObserve: true},
+		{name: "unknown namespace", template: "team-c", namespace: "team-b"},
+		{name: "granted watched namespace", namespaces: []string{"team-a", "platform"}, template: "platform",
+			namespace: "team-a", canObserve: true},
+		{name: "other watched namespace", namespaces: []string{"team-a", "platform"}, template: "team-a",
+			namespace: "platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access := newNamespaceAccess(kubeClient, watchNamespaces(Options{WatchNamespaces: tt.namespaces}))
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			go access.informer.Run(stopCh)
+
+			if !cache.WaitForCacheSync(stopCh, access.informer.HasSynced) {
+				t.Fatalf("Timed out waiting for caches to sync")
+			}
+
+			template := &v1alpha1.EventTriggeredJob{ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: tt.template}}
+			if canObserve := access.canObserve(template, tt.namespace); canObserve != tt.canObserve {
+				t.Errorf("Expected canObserve(%s, %q) = %v, got %v", tt.template, tt.namespace, tt.canObserve, canObserve)
+			}
+		})
+	}
+}
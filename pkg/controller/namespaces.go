@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchNamespaces returns the namespaces the controllers watch, a single metav1.NamespaceAll unless options restrict them
+func watchNamespaces(options Options) []string {
+	if len(options.WatchNamespaces) == 0 {
+		return []string{metav1.NamespaceAll}
+	}
+
+	return options.WatchNamespaces
+}
+
+// namespacedInformer runs one informer per watched namespace, so it only needs permissions in those namespaces.
+// Without restrictions it runs a single informer for all namespaces.
+type namespacedInformer struct {
+	informers map[string]cache.SharedIndexInformer
+}
+
+// newNamespacedInformer creates the informers of namespaces with newInformer
+func newNamespacedInformer(namespaces []string, newInformer func(namespace string) cache.SharedIndexInformer) *namespacedInformer {
+	informers := make(map[string]cache.SharedIndexInformer, len(namespaces))
+	for _, namespace := range namespaces {
+		informers[namespace] = newInformer(namespace)
+	}
+
+	return &namespacedInformer{informers: informers}
+}
+
+// AddEventHandlerWithResyncPeriod adds handler to the informers of all namespaces
+func (i *namespacedInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	for _, informer := range i.informers {
+		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+// Run runs the informers of all namespaces until stopCh is closed
+func (i *namespacedInformer) Run(stopCh <-chan struct{}) {
+	for _, informer := range i.informers {
+		go informer.Run(stopCh)
+	}
+
+	<-stopCh
+}
+
+// HasSynced checks if the informers of all namespaces have synced their caches
+func (i *namespacedInformer) HasSynced() bool {
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetByKey gets an object by its namespace/name key from the informer of its namespace
+func (i *namespacedInformer) GetByKey(key string) (interface{}, bool, error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	informer, ok := i.informers[namespace]
+	if !ok {
+		informer, ok = i.informers[metav1.NamespaceAll]
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	return informer.GetStore().GetByKey(key)
+}
+
+// ListKeys returns the keys of the objects cached for all namespaces
+func (i *namespacedInformer) ListKeys() []string {
+	var keys []string
+	for _, informer := range i.informers {
+		keys = append(keys, informer.GetStore().ListKeys()...)
+	}
+
+	return keys
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWatchNamespaces(t *testing.T) {
+	if namespaces := watchNamespaces(Options{}); len(namespaces) != 1 || namespaces[0] != metav1.NamespaceAll {
+		t.Errorf("Expected all namespaces to be watched, got %v", namespaces)
+	}
+
+	options := Options{WatchNamespaces: []string{"team-a", "team-b"}}
+	if namespaces := watchNamespaces(options); len(namespaces) != 2 {
+		t.Errorf("Expected namespaces team-a and team-b to be watched, got %v", namespaces)
+	}
+}
+
+func TestNamespacedInformerWatchesOnlyItsNamespaces(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-a", Namespace: "team-a"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-b", Namespace: "team-b"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-c", Namespace: "team-c"}},
+	)
+
+	informer := newNamespacedInformer([]string{"team-a", "team-b"}, func(namespace string) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return kubeClient.CoreV1().Events(namespace).List(context.Background(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return kubeClient.CoreV1().Events(namespace).Watch(context.Background(), options)
+				},
+			},
+			&corev1.Event{},
+			0,
+			cache.Indexers{},
+		)
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		t.Fatalf("Timed out waiting for caches to sync")
+	}
+
+	if keys := informer.ListKeys(); len(keys) != 2 {
+		t.Errorf("Expected the events of team-a and team-b, got %v", keys)
+	}
+	if _, exists, err := informer.GetByKey("team-b/event-b"); err != nil || !exists {
+		t.Errorf("Expected event team-b/event-b to be cached, got exists=%v err=%v", exists, err)
+	}
+	if _, exists, _ := informer.GetByKey("team-c/event-c"); exists {
+		t.Errorf("Expected event team-c/event-c of an unwatched namespace not to be cached")
+	}
+}
+
+func TestTemplateListerWatchesOnlyItsNamespaces(t *testing.T) {
+	kubananaClient := kubananafake.NewSimpleClientset(
+		&v1alpha1.EventTriggeredJob{ObjectMeta: metav1.ObjectMeta{Name: "template-a", Namespace: "team-a"}},
+		&v1alpha1.EventTriggeredJob{ObjectMeta: metav1.ObjectMeta{Name: "template-c", Namespace: "team-c"}},
+	)
+
+	informer, lister := newTemplateInformer(kubananaClient, []string{"team-a", "team-b"}, 0)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		t.Fatalf("Timed out waiting for caches to sync")
+	}
+
+	templates, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("Failed to list templates: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "template-a" {
+		t.Errorf("Expected only template-a, got %d templates", len(templates))
+	}
+
+	if _, err := lister.EventTriggeredJobs("team-a").Get("template-a"); err != nil {
+		t.Errorf("Expected to get template-a: %v", err)
+	}
+	if _, err := lister.EventTriggeredJobs("team-c").Get("template-c"); err == nil {
+		t.Errorf("Expected template-c of an unwatched namespace not to be found")
+	}
+}
+
+func TestHistoryControllerOnlyPrunesWatchedNamespaces(t *testing.T) {
+	limit := int32(0)
+	kubananaClient := kubananafake.NewSimpleClientset(
+		&v1alpha1.EventTriggeredJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "team-a"},
+			Spec:       v1alpha1.EventTriggeredJobSpec{SuccessfulJobsHistoryLimit: &limit},
+		},
+	)
+
+	watched := newFinishedTestJob("watched-job", batchv1.JobComplete, time.Now())
+	watched.Namespace = "team-a"
+	unwatched := newFinishedTestJob("unwatched-job", batchv1.JobComplete, time.Now())
+	unwatched.Namespace = "team-c"
+	kubeClient := fake.NewSimpleClientset(watched, unwatched)
+
+	controller := NewHistoryControllerWithOptions(kubeClient, kubananaClient, time.Minute,
+		Options{WatchNamespaces: []string{"team-a"}})
+	if err := controller.cleanup(); err != nil {
+		t.Fatalf("Failed to clean up job history: %v", err)
+	}
+
+	if _, err := kubeClient.BatchV1().Jobs("team-a").Get(context.Background(), "watched-job", metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected the job in the watched namespace to be pruned")
+	}
+	if _, err := kubeClient.BatchV1().Jobs("team-c").Get(context.Background(), "unwatched-job", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the job in the unwatched namespace to be kept: %v", err)
+	}
+}
+
+func TestHistoryControllerContinuesAfterFailedNamespace(t *testing.T) {
+	limit := int32(0)
+	kubananaClient := kubananafake.NewSimpleClientset(
+		&v1alpha1.EventTriggeredJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "team-b"},
+			Spec:       v1alpha1.EventTriggeredJobSpec{SuccessfulJobsHistoryLimit: &limit},
+		},
+	)
+	kubananaClient.PrependReactor("list", "eventtriggeredjobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "team-a" {
+			return true, nil, fmt.Errorf("forbidden")
+		}
+		return false, nil, nil
+	})
+
+	job := newFinishedTestJob("finished-job", batchv1.JobComplete, time.Now())
+	job.Namespace = "team-b"
+	kubeClient := fake.NewSimpleClientset(job)
+
+	controller := NewHistoryControllerWithOptions(kubeClient, kubananaClient, time.Minute,
+		Options{WatchNamespaces: []string{"team-a", "team-b"}})
+	err := controller.cleanup()
+	if err == nil || !strings.Contains(err.Error(), "team-a") {
+		t.Errorf("Expected the failed namespace to be reported, got %v", err)
+	}
+
+	if _, err := kubeClient.BatchV1().Jobs("team-b").Get(context.Background(), "finished-job", metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected the job in the namespace after the failed one to be pruned")
+	}
+}
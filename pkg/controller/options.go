@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/audit"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// Options holds controller-wide settings shared by the event and status controllers
+type Options struct {
+	// DefaultJobTTLSeconds is used as ttlSecondsAfterFinished for created jobs whose
+	// template doesn't set one. Nil disables the default.
+	DefaultJobTTLSeconds *int32
+
+	// DefaultJobBackoffLimit is used as backoffLimit for created jobs whose template doesn't
+	// set one. Nil leaves it to the Job API.
+	DefaultJobBackoffLimit *int32
+
+	// WatchNamespaces restricts the controllers to events, resources and templates of these
+	// namespaces, so they only need namespace-scoped permissions. Empty watches all namespaces.
+	WatchNamespaces []string
+
+	// InvolvedObjectEvents also emits trigger events on the object that triggered the template
+	InvolvedObjectEvents bool
+
+	// ImpersonatedClient returns the client used to create jobs of templates that set a
+	// serviceAccountName. Nil makes such templates fail to create jobs.
+	ImpersonatedClient ClientForUser
+
+	// RequireServiceAccount only creates jobs for templates that set a serviceAccountName
+	RequireServiceAccount bool
+
+	// DryRun evaluates triggers as usual but creates and deletes jobs with a server-side dry run,
+	// so nothing but the templates' status is persisted
+	DryRun bool
+
+	// AuditLog receives a record of every decision taken for a trigger that matched a template.
+	// Nil disables the audit log.
+	AuditLog *audit.Log
+
+	// TriggerHistoryLimit is the number of trigger records kept in each template's status.
+	// Zero keeps none.
+	TriggerHistoryLimit int
+
+	// LabelPrefix replaces DefaultLabelPrefix in the labels of created jobs. Empty keeps the default.
+	LabelPrefix string
+
+	// AnnotationPrefix replaces DefaultAnnotationPrefix in the annotations of created jobs and their
+	// pods. Empty keeps the default.
+	AnnotationPrefix string
+
+	// EventResyncPeriod is how often the event controller's informers resync. Zero disables resyncs.
+	EventResyncPeriod time.Duration
+
+	// StatusResyncPeriod is how often the status controller's informers resync. Zero disables resyncs.
+	StatusResyncPeriod time.Duration
+}
+
+// applyJobDefaults fills in controller-wide defaults that the template left unset
+func applyJobDefaults(job *batchv1.Job, options Options) {
+	if job.Spec.TTLSecondsAfterFinished == nil && options.DefaultJobTTLSeconds != nil {
+		ttl := *options.DefaultJobTTLSeconds
+		job.Spec.TTLSecondsAfterFinished = &ttl
+	}
+	if job.Spec.BackoffLimit == nil && options.DefaultJobBackoffLimit != nil {
+		backoffLimit := *options.DefaultJobBackoffLimit
+		job.Spec.BackoffLimit = &backoffLimit
+	}
+}
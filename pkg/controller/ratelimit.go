@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+)
+
+const (
+	// defaultRateLimitInterval is used when a template sets maxJobs without an interval
+	defaultRateLimitInterval = time.Minute
+
+	// dropReasonRateLimited is recorded when a template exceeded its maxJobs per interval
+	dropReasonRateLimited = "RateLimited"
+
+	// dropReasonCooldown is recorded when a resource triggered again within its cooldown
+	dropReasonCooldown = "ResourceCooldown"
+)
+
+// triggerLimiter enforces the per-template and per-resource rate limits of templates
+type triggerLimiter struct {
+	mu sync.Mutex
+	// jobTimes tracks the creation times of recent jobs per template
+	jobTimes map[string][]time.Time
+	// cooldownUntil tracks when the cooldown ends per template and resource
+	cooldownUntil map[string]time.Time
+	now           func() time.Time
+}
+
+// newTriggerLimiter creates a new triggerLimiter
+func newTriggerLimiter() *triggerLimiter {
+	return &triggerLimiter{
+		jobTimes:      make(map[string][]time.Time),
+		cooldownUntil: make(map[string]time.Time),
+		now:           time.Now,
+	}
+}
+
+// allow checks if a job may be created for the template and resource, returning the reason for
+// dropping the trigger if not. An allowed trigger reserves its slot right away, so concurrent
+// triggers can't all pass the limits before any job is created. The returned release gives the
+// slot back if no job is created after all.
+func (l *triggerLimiter) allow(template *v1alpha1.EventTriggeredJob, resourceKey string) (func(), bool, string) {
+	rateLimit := template.Spec.RateLimit
+	if rateLimit == nil {
+		return func() {}, true, ""
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	templateKey, resourceJobKey := limiterKeys(template, resourceKey)
+	cooldown := resourceCooldown(rateLimit)
+
+	// Check the cooldown for the triggering resource
+	if cooldown > 0 {
+		if until, exists := l.cooldownUntil[resourceJobKey]; exists && now.Before(until) {
+			return nil, false, dropReasonCooldown
+		}
+	}
+
+	// Check the maximum number of jobs per interval for the template
+	if rateLimit.MaxJobs > 0 {
+		interval := defaultRateLimitInterval
+		if rateLimit.Interval != nil && rateLimit.Interval.Duration > 0 {
+			interval = rateLimit.Interval.Duration
+		}
+
+		recent := []time.Time{}
+		for _, t := range l.jobTimes[templateKey] {
+			if now.Sub(t) < interval {
+				recent = append(recent, t)
+			}
+		}
+
+		if len(recent) >= int(rateLimit.MaxJobs) {
+			l.jobTimes[templateKey] = recent
+			return nil, false, dropReasonRateLimited
+		}
+		l.jobTimes[templateKey] = append(recent, now)
+	}
+
+	if cooldown > 0 {
+		l.cooldownUntil[resourceJobKey] = now.Add(cooldown)
+		l.forgetExpired(now)
+	}
+
+	return func() { l.release(templateKey, resourceJobKey, now) }, true, ""
+}
+
+// release gives back the slot reserved at the given time for a trigger that didn't create a job
+func (l *triggerLimiter) release(templateKey, resourceJobKey string, reservedAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	times := l.jobTimes[templateKey]
+	for i, t := range times {
+		if t.Equal(reservedAt) {
+			l.jobTimes[templateKey] = append(times[:i:i], times[i+1:]...)
+			break
+		}
+	}
+
+	// The cooldown was free when the slot was reserved, so nothing else can have started it since
+	delete(l.cooldownUntil, resourceJobKey)
+}
+
+// limiterKeys returns the keys the limits of the template and of the resource are tracked under
+func limiterKeys(template *v1alpha1.EventTriggeredJob, resourceKey string) (string, string) {
+	templateKey := template.Namespace + "/" + template.Name
+	return templateKey, templateKey + "/" + resourceKey
+}
+
+// resourceCooldown returns the cooldown between jobs for the same resource, 0 if there is none
+func resourceCooldown(rateLimit *v1alpha1.RateLimit) time.Duration {
+	if rateLimit.ResourceCooldown == nil {
+		return 0
+	}
+	return rateLimit.ResourceCooldown.Duration
+}
+
+// forgetExpired removes resources whose cooldown has passed to keep memory bounded
+func (l *triggerLimiter) forgetExpired(now time.Time) {
+	// Only sweep once enough resources have accumulated
+	if len(l.cooldownUntil) < 1024 {
+		return
+	}
+
+	for key, until := range l.cooldownUntil {
+		if !now.Before(until) {
+			delete(l.cooldownUntil, key)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newRateLimitedTemplate creates a template with the given rate limit
+func newRateLimitedTemplate(rateLimit *v1alpha1.RateLimit) *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			RateLimit: rateLimit,
+		},
+	}
+}
+
+// allowAndCreate checks the limits for a trigger and keeps its slot as if a job was created
+func allowAndCreate(limiter *triggerLimiter, template *v1alpha1.EventTriggeredJob, resourceKey string) (bool, string) {
+	_, allowed, reason := limiter.allow(template, resourceKey)
+	return allowed, reason
+}
+
+func TestTriggerLimiterWithoutRateLimit(t *testing.T) {
+	limiter := newTriggerLimiter()
+	template := newRateLimitedTemplate(nil)
+
+	for i := 0; i < 100; i++ {
+		if allowed, _ := allowAndCreate(limiter, template, "Pod/default/test-pod"); !allowed {
+			t.Fatalf("Expected trigger %d to be allowed without a rate limit", i)
+		}
+	}
+}
+
+func TestTriggerLimiterMaxJobs(t *testing.T) {
+	now := time.Now()
+	limiter := newTriggerLimiter()
+	limiter.now = func() time.Time { return now }
+
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{
+		MaxJobs:  2,
+		Interval: &metav1.Duration{Duration: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-"+string(rune('a'+i))); !allowed {
+			t.Fatalf("Expected trigger %d to be allowed", i)
+		}
+	}
+
+	allowed, reason := allowAndCreate(limiter, template, "Pod/default/pod-c")
+	if allowed {
+		t.Errorf("Expected trigger beyond maxJobs to be dropped")
+	}
+	if reason != dropReasonRateLimited {
+		t.Errorf("Expected reason %s, got %s", dropReasonRateLimited, reason)
+	}
+
+	// Once the interval has passed, jobs are allowed again
+	now = now.Add(time.Minute)
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-c"); !allowed {
+		t.Errorf("Expected trigger to be allowed after the interval passed")
+	}
+}
+
+func TestTriggerLimiterResourceCooldown(t *testing.T) {
+	now := time.Now()
+	limiter := newTriggerLimiter()
+	limiter.now = func() time.Time { return now }
+
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{
+		ResourceCooldown: &metav1.Duration{Duration: 30 * time.Second},
+	})
+
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-a"); !allowed {
+		t.Fatalf("Expected first trigger to be allowed")
+	}
+
+	allowed, reason := allowAndCreate(limiter, template, "Pod/default/pod-a")
+	if allowed {
+		t.Errorf("Expected trigger within the cooldown to be dropped")
+	}
+	if reason != dropReasonCooldown {
+		t.Errorf("Expected reason %s, got %s", dropReasonCooldown, reason)
+	}
+
+	// Other resources are not affected by the cooldown
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-b"); !allowed {
+		t.Errorf("Expected trigger for another resource to be allowed")
+	}
+
+	now = now.Add(30 * time.Second)
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-a"); !allowed {
+		t.Errorf("Expected trigger to be allowed after the cooldown passed")
+	}
+}
+
+func TestTriggerLimiterDroppedTriggersDontCount(t *testing.T) {
+	now := time.Now()
+	limiter := newTriggerLimiter()
+	limiter.now = func() time.Time { return now }
+
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{
+		MaxJobs:          1,
+		ResourceCooldown: &metav1.Duration{Duration: time.Hour},
+	})
+
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-a"); !allowed {
+		t.Fatalf("Expected first trigger to be allowed")
+	}
+
+	// Dropped by maxJobs, so pod-b must not start a cooldown
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-b"); allowed {
+		t.Fatalf("Expected second trigger to be dropped by maxJobs")
+	}
+
+	now = now.Add(time.Minute)
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-b"); !allowed {
+		t.Errorf("Expected pod-b to be allowed once the interval passed")
+	}
+}
+
+func TestTriggerLimiterReleasesUncreatedJobs(t *testing.T) {
+	limiter := newTriggerLimiter()
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{
+		MaxJobs:          1,
+		ResourceCooldown: &metav1.Duration{Duration: time.Hour},
+	})
+
+	// Triggers that are allowed but don't create a job, e.g. forbidden by the concurrency policy,
+	// give their slot back and neither use up the budget nor start the cooldown
+	for i := 0; i < 3; i++ {
+		release, allowed, reason := limiter.allow(template, "Pod/default/pod-a")
+		if !allowed {
+			t.Fatalf("Expected released trigger %d to be allowed, got %s", i, reason)
+		}
+		release()
+	}
+
+	if allowed, _ := allowAndCreate(limiter, template, "Pod/default/pod-a"); !allowed {
+		t.Fatalf("Expected the trigger to be allowed")
+	}
+	if _, allowed, reason := limiter.allow(template, "Pod/default/pod-a"); allowed || reason != dropReasonCooldown {
+		t.Errorf("Expected the created job to start the cooldown, got %v %s", allowed, reason)
+	}
+	if _, allowed, reason := limiter.allow(template, "Pod/default/pod-b"); allowed || reason != dropReasonRateLimited {
+		t.Errorf("Expected the created job to use up the budget, got %v %s", allowed, reason)
+	}
+}
+
+func TestTriggerLimiterReservesConcurrentTriggers(t *testing.T) {
+	limiter := newTriggerLimiter()
+	template := newRateLimitedTemplate(&v1alpha1.RateLimit{MaxJobs: 2, Interval: &metav1.Duration{Duration: time.Hour}})
+
+	// All triggers check the limits before any of their jobs is created
+	var wg sync.WaitGroup
+	var allowedCount atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if allowed, _ := allowAndCreate(limiter, template, fmt.Sprintf("Pod/default/pod-%d", i)); allowed {
+				allowedCount.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if allowedCount.Load() != 2 {
+		t.Errorf("Expected only maxJobs concurrent triggers to be allowed, got %d", allowedCount.Load())
+	}
+}
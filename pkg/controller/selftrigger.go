@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// triggerDepthAnnotation records how many jobs deep in a self trigger chain a job is
+	triggerDepthAnnotation = "kubanana.roshanbhatia.com/trigger-depth"
+
+	// maxTriggerDepthAnnotation carries the depth limit of the template that started a chain
+	maxTriggerDepthAnnotation = "kubanana.roshanbhatia.com/max-trigger-depth"
+
+	// defaultMaxTriggerDepth is used for templates that allow self triggers without a limit
+	defaultMaxTriggerDepth int32 = 3
+
+	// maxOwnerChainLength bounds how many owners are followed to find a created job
+	maxOwnerChainLength = 3
+
+	// dropReasonMaxTriggerDepth is recorded when a self trigger chain gets too deep
+	dropReasonMaxTriggerDepth = "MaxTriggerDepthExceeded"
+)
+
+// triggerOrigin describes whether the object behind a trigger was created by Kubanana
+type triggerOrigin struct {
+	// selfCreated is set for jobs created from a template and their pods
+	selfCreated bool
+
+	// depth is the trigger depth of the job the object belongs to
+	depth int32
+
+	// maxDepth is the depth limit inherited from the start of the chain, if any
+	maxDepth *int32
+}
+
+// resolveTriggerOrigin checks if obj is a job created by Kubanana, or is controlled by one
+// through its owner chain
+func resolveTriggerOrigin(ctx context.Context, kubeClient kubernetes.Interface, options Options, obj metav1.Object) triggerOrigin {
+	if obj == nil {
+		return triggerOrigin{}
+	}
+
+	for i := 0; i <= maxOwnerChainLength; i++ {
+		if origin, ok := originFromObjectMeta(obj, options); ok {
+			return origin
+		}
+
+		owner := metav1.GetControllerOfNoCopy(obj)
+		if owner == nil || i == maxOwnerChainLength {
+			break
+		}
+
+		var err error
+		obj, err = lookupObjectMeta(ctx, kubeClient, owner.Kind, obj.GetNamespace(), owner.Name)
+		if err != nil || obj == nil {
+			break
+		}
+	}
+
+	return triggerOrigin{}
+}
+
+// originFromObjectMeta reads the trigger origin from the labels and annotations Kubanana puts on
+// created jobs and their pods
+func originFromObjectMeta(obj metav1.Object, options Options) (triggerOrigin, bool) {
+	if _, ok := obj.GetLabels()[options.labelKey(TemplateLabel)]; !ok {
+		return triggerOrigin{}, false
+	}
+
+	origin := triggerOrigin{selfCreated: true, depth: 1}
+	annotations := obj.GetAnnotations()
+
+	if value, ok := annotations[options.annotationKey(triggerDepthAnnotation)]; ok {
+		if depth, err := strconv.ParseInt(value, 10, 32); err == nil {
+			origin.depth = int32(depth)
+		}
+	}
+
+	if value, ok := annotations[options.annotationKey(maxTriggerDepthAnnotation)]; ok {
+		if maxDepth, err := strconv.ParseInt(value, 10, 32); err == nil {
+			limit := int32(maxDepth)
+			origin.maxDepth = &limit
+		}
+	}
+
+	return origin, true
+}
+
+// maxTriggerDepth returns the depth limit for a chain, preferring the one inherited from its start
+func maxTriggerDepth(template *v1alpha1.EventTriggeredJob, origin triggerOrigin) int32 {
+	if origin.maxDepth != nil {
+		return *origin.maxDepth
+	}
+	if template.Spec.MaxTriggerDepth != nil {
+		return *template.Spec.MaxTriggerDepth
+	}
+	return defaultMaxTriggerDepth
+}
+
+// checkSelfTrigger reports whether a trigger with origin may run the template. Objects created by
+// Kubanana are ignored unless the template allows self triggers; in that case a non-empty drop
+// reason is returned once the chain reached its maximum depth.
+func checkSelfTrigger(template *v1alpha1.EventTriggeredJob, origin triggerOrigin) (bool, string) {
+	if !origin.selfCreated {
+		return true, ""
+	}
+
+	if !template.Spec.AllowSelfTrigger {
+		klog.V(4).InfoS("Ignoring trigger, object was created by Kubanana", logKeyTemplate, klog.KObj(template))
+		return false, ""
+	}
+
+	if origin.depth >= maxTriggerDepth(template, origin) {
+		return false, dropReasonMaxTriggerDepth
+	}
+
+	return true, ""
+}
+
+// applyTriggerOrigin marks the job and its pods as created by the template and records their
+// place in the trigger chain
+func applyTriggerOrigin(job *batchv1.Job, template *v1alpha1.EventTriggeredJob, origin triggerOrigin) {
+	depth := strconv.FormatInt(int64(origin.depth+1), 10)
+	maxDepth := strconv.FormatInt(int64(maxTriggerDepth(template, origin)), 10)
+
+	// The pod template still shares its maps with the template, so they're copied before changing them
+	podMeta := &job.Spec.Template.ObjectMeta
+	podMeta.Labels = copyStringMap(podMeta.Labels)
+	podMeta.Labels[TemplateLabel] = template.Name
+
+	for _, objMeta := range []*metav1.ObjectMeta{&job.ObjectMeta, podMeta} {
+		objMeta.Annotations = copyStringMap(objMeta.Annotations)
+		objMeta.Annotations[triggerDepthAnnotation] = depth
+		objMeta.Annotations[maxTriggerDepthAnnotation] = maxDepth
+	}
+}
+
+// copyStringMap returns a non-nil copy of m
+func copyStringMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m)+2)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveTriggerOrigin(t *testing.T) {
+	isController := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template-pod-create",
+			Namespace: "default",
+			Labels:    map[string]string{TemplateLabel: "test-template"},
+			Annotations: map[string]string{
+				triggerDepthAnnotation:    "2",
+				maxTriggerDepthAnnotation: "5",
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset(job)
+
+	// Pods of jobs created before their pods were labelled are found through their owner
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template-pod-create-abc",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "batch/v1", Kind: "Job", Name: job.Name, Controller: &isController},
+			},
+		},
+	}
+
+	origin := resolveTriggerOrigin(context.Background(), kubeClient, Options{}, pod)
+	if !origin.selfCreated {
+		t.Fatalf("Expected pod of a created job to be recognized")
+	}
+	if origin.depth != 2 {
+		t.Errorf("Expected depth 2, got %d", origin.depth)
+	}
+	if origin.maxDepth == nil || *origin.maxDepth != 5 {
+		t.Errorf("Expected inherited max depth 5, got %v", origin.maxDepth)
+	}
+
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	if origin := resolveTriggerOrigin(context.Background(), kubeClient, Options{}, other); origin.selfCreated {
+		t.Errorf("Expected unrelated pod not to be recognized")
+	}
+
+	if origin := resolveTriggerOrigin(context.Background(), kubeClient, Options{}, nil); origin.selfCreated {
+		t.Errorf("Expected missing object not to be recognized")
+	}
+}
+
+func TestCheckSelfTrigger(t *testing.T) {
+	maxDepth := int32(2)
+	inherited := int32(1)
+
+	tests := []struct {
+		name            string
+		allow           bool
+		maxTriggerDepth *int32
+		origin          triggerOrigin
+		allowed         bool
+		reason          string
+	}{
+		{
+			name:    "not created by kubanana",
+			origin:  triggerOrigin{},
+			allowed: true,
+		},
+		{
+			name:    "ignored by default",
+			origin:  triggerOrigin{selfCreated: true, depth: 1},
+			allowed: false,
+		},
+		{
+			name:            "allowed below max depth",
+			allow:           true,
+			maxTriggerDepth: &maxDepth,
+			origin:          triggerOrigin{selfCreated: true, depth: 1},
+			allowed:         true,
+		},
+		{
+			name:            "max depth reached",
+			allow:           true,
+			maxTriggerDepth: &maxDepth,
+			origin:          triggerOrigin{selfCreated: true, depth: 2},
+			allowed:         false,
+			reason:          dropReasonMaxTriggerDepth,
+		},
+		{
+			name:            "inherited max depth wins",
+			allow:           true,
+			maxTriggerDepth: &maxDepth,
+			origin:          triggerOrigin{selfCreated: true, depth: 1, maxDepth: &inherited},
+			allowed:         false,
+			reason:          dropReasonMaxTriggerDepth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &v1alpha1.EventTriggeredJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+				Spec: v1alpha1.EventTriggeredJobSpec{
+					AllowSelfTrigger: tt.allow,
+					MaxTriggerDepth:  tt.maxTriggerDepth,
+				},
+			}
+
+			allowed, reason := checkSelfTrigger(template, tt.origin)
+			if allowed != tt.allowed || reason != tt.reason {
+				t.Errorf("checkSelfTrigger() = %v, %q, want %v, %q", allowed, reason, tt.allowed, tt.reason)
+			}
+		})
+	}
+}
+
+func TestApplyTriggerOrigin(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "cleanup"}},
+					},
+				},
+			},
+		},
+	}
+
+	job := &batchv1.Job{Spec: template.Spec.JobTemplate.Spec}
+	applyTriggerOrigin(job, template, triggerOrigin{selfCreated: true, depth: 1})
+
+	podMeta := job.Spec.Template.ObjectMeta
+	if podMeta.Labels[TemplateLabel] != "test-template" || podMeta.Labels["app"] != "cleanup" {
+		t.Errorf("Expected pods to keep their labels and get the template label, got %v", podMeta.Labels)
+	}
+	for _, annotations := range []map[string]string{job.Annotations, podMeta.Annotations} {
+		if annotations[triggerDepthAnnotation] != "2" {
+			t.Errorf("Expected trigger depth 2, got %q", annotations[triggerDepthAnnotation])
+		}
+		if annotations[maxTriggerDepthAnnotation] != "3" {
+			t.Errorf("Expected default max trigger depth 3, got %q", annotations[maxTriggerDepthAnnotation])
+		}
+	}
+
+	if _, exists := template.Spec.JobTemplate.Spec.Template.Labels[TemplateLabel]; exists {
+		t.Errorf("Expected the template's pod labels not to be modified")
+	}
+}
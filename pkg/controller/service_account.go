@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ClientForUser returns a client that acts as the given user
+type ClientForUser func(username string) (kubernetes.Interface, error)
+
+// ImpersonatingClients returns clients that impersonate users on top of cfg, reusing the client of each user
+func ImpersonatingClients(cfg *rest.Config) ClientForUser {
+	var mu sync.Mutex
+	clients := map[string]kubernetes.Interface{}
+
+	return func(username string) (kubernetes.Interface, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if client, ok := clients[username]; ok {
+			return client, nil
+		}
+
+		impersonated := rest.CopyConfig(cfg)
+		impersonated.Impersonate = rest.ImpersonationConfig{UserName: username}
+		client, err := kubernetes.NewForConfig(impersonated)
+		if err != nil {
+			return nil, err
+		}
+
+		clients[username] = client
+		return client, nil
+	}
+}
+
+// serviceAccountUsername returns the username a service account authenticates as
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// jobClient returns the client to create the template's jobs with, impersonating its service account if it sets one
+func jobClient(kubeClient kubernetes.Interface, options Options, template *v1alpha1.EventTriggeredJob) (kubernetes.Interface, error) {
+	serviceAccount := template.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		if options.RequireServiceAccount {
+			return nil, fmt.Errorf("template %s/%s must set serviceAccountName", template.Namespace, template.Name)
+		}
+		return kubeClient, nil
+	}
+
+	if options.ImpersonatedClient == nil {
+		return nil, fmt.Errorf("impersonating service account %s is not configured", serviceAccount)
+	}
+
+	return options.ImpersonatedClient(serviceAccountUsername(template.Namespace, serviceAccount))
+}
+
+// checkJobServiceAccount makes the job's pods run as the template's service account. Pods without
+// a service account default to it, pods asking for another one or outside the template's namespace
+// are rejected.
+func checkJobServiceAccount(job *batchv1.Job, template *v1alpha1.EventTriggeredJob) error {
+	serviceAccount := template.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		return nil
+	}
+
+	// Templates admitted before jobNamespacePolicy was validated may still ask for another namespace
+	if job.Namespace != template.Namespace {
+		return fmt.Errorf("jobs running as service account %s must be created in namespace %s, not %s",
+			serviceAccount, template.Namespace, job.Namespace)
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.ServiceAccountName == "" {
+		podSpec.ServiceAccountName = serviceAccount
+	}
+	if podSpec.ServiceAccountName != serviceAccount {
+		return fmt.Errorf("pods must run as service account %s, not %s", serviceAccount, podSpec.ServiceAccountName)
+	}
+
+	return nil
+}
+
+// createJob creates the job with the template's service account, or the controller's own
+// permissions if the template doesn't set one. Dry-run templates are only created server-side.
+func createJob(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	options Options,
+	template *v1alpha1.EventTriggeredJob,
+	job *batchv1.Job) (*batchv1.Job, error) {
+
+	if err := checkJobServiceAccount(job, template); err != nil {
+		return nil, err
+	}
+
+	client, err := jobClient(kubeClient, options, template)
+	if err != nil {
+		return nil, err
+	}
+
+	// Labels and annotations are rendered with the default prefixes, move them to the configured ones
+	applyKeyPrefixes(job, options)
+
+	createdJob, err := client.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{
+		DryRun: dryRunOption(isDryRun(options, template)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return createdJob, nil
+}
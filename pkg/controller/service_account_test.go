@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// newServiceAccountTestTemplate creates a template that impersonates serviceAccount
+func newServiceAccountTestTemplate(serviceAccount string) *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "team-a"},
+		Spec:       v1alpha1.EventTriggeredJobSpec{ServiceAccountName: serviceAccount},
+	}
+}
+
+// newServiceAccountTestJob creates a job whose pods run as serviceAccount
+func newServiceAccountTestJob(serviceAccount string) *batchv1.Job {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "team-a"}}
+	job.Spec.Template.Spec.ServiceAccountName = serviceAccount
+	return job
+}
+
+func TestCreateJobImpersonatesServiceAccount(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	impersonatedClient := fake.NewSimpleClientset()
+
+	var usernames []string
+	options := Options{
+		ImpersonatedClient: func(username string) (kubernetes.Interface, error) {
+			usernames = append(usernames, username)
+			return impersonatedClient, nil
+		},
+	}
+
+	job, err := createJob(context.Background(), kubeClient, options,
+		newServiceAccountTestTemplate("deployer"), newServiceAccountTestJob(""))
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	if len(usernames) != 1 || usernames[0] != "system:serviceaccount:team-a:deployer" {
+		t.Errorf("Expected to impersonate system:serviceaccount:team-a:deployer, got %v", usernames)
+	}
+	if job.Spec.Template.Spec.ServiceAccountName != "deployer" {
+		t.Errorf("Expected pods to default to service account deployer, got %q", job.Spec.Template.Spec.ServiceAccountName)
+	}
+
+	if _, err := impersonatedClient.BatchV1().Jobs("team-a").Get(context.Background(), "test-job", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the job to be created by the impersonated client: %v", err)
+	}
+	if jobs, _ := kubeClient.BatchV1().Jobs("team-a").List(context.Background(), metav1.ListOptions{}); len(jobs.Items) != 0 {
+		t.Errorf("Expected no job to be created with the controller's permissions, got %d", len(jobs.Items))
+	}
+}
+
+func TestCreateJobWithoutServiceAccount(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	if _, err := createJob(context.Background(), kubeClient, Options{},
+		newServiceAccountTestTemplate(""), newServiceAccountTestJob("")); err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if _, err := kubeClient.BatchV1().Jobs("team-a").Get(context.Background(), "test-job", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the job to be created with the controller's permissions: %v", err)
+	}
+}
+
+func TestCreateJobRejectsServiceAccount(t *testing.T) {
+	impersonated := Options{
+		ImpersonatedClient: func(username string) (kubernetes.Interface, error) {
+			return fake.NewSimpleClientset(), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  Options
+		template *v1alpha1.EventTriggeredJob
+		job      *batchv1.Job
+	}{
+		{
+			name:     "pods run as another service account",
+			options:  impersonated,
+			template: newServiceAccountTestTemplate("deployer"),
+			job:      newServiceAccountTestJob("admin"),
+		},
+		{
+			name:     "job outside the service account's namespace",
+			options:  impersonated,
+			template: newServiceAccountTestTemplate("deployer"),
+			job: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "prod"},
+			},
+		},
+		{
+			name:     "service account required",
+			options:  Options{RequireServiceAccount: true},
+			template: newServiceAccountTestTemplate(""),
+			job:      newServiceAccountTestJob(""),
+		},
+		{
+			name:     "impersonation not configured",
+			options:  Options{},
+			template: newServiceAccountTestTemplate("deployer"),
+			job:      newServiceAccountTestJob(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+
+			if _, err := createJob(context.Background(), kubeClient, tt.options, tt.template, tt.job); err == nil {
+				t.Error("Expected job creation to fail")
+			}
+			if jobs, _ := kubeClient.BatchV1().Jobs("").List(context.Background(), metav1.ListOptions{}); len(jobs.Items) != 0 {
+				t.Errorf("Expected no job to be created, got %d", len(jobs.Items))
+			}
+		})
+	}
+}
+
+func TestImpersonatingClientsReusesClients(t *testing.T) {
+	clientForUser := ImpersonatingClients(&rest.Config{Host: "https://kubernetes.default.svc"})
+
+	first, err := clientForUser("system:serviceaccount:team-a:deployer")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	second, _ := clientForUser("system:serviceaccount:team-a:deployer")
+	other, _ := clientForUser("system:serviceaccount:team-b:deployer")
+
+	if first != second {
+		t.Errorf("Expected the client of a user to be reused")
+	}
+	if first == other {
+		t.Errorf("Expected different users to get different clients")
+	}
+}
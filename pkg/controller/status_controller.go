@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	kubananalisters "github.com/roshbhatia/kubanana/pkg/client/listers/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,30 +26,77 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
 // StatusController watches resource status changes and triggers jobs when conditions match
 type StatusController struct {
-	kubeClient     kubernetes.Interface
-	dynamicClient  dynamic.Interface
-	workqueue      workqueue.RateLimitingInterface
-	informers      map[schema.GroupVersionKind]cache.SharedIndexInformer
-	templates      []v1alpha1.EventTriggeredJob
-	resourceStatus map[string]map[string]string // Tracks resource statuses
+	kubeClient       kubernetes.Interface
+	kubananaClient   versioned.Interface
+	dynamicClient    dynamic.Interface
+	workqueue        workqueue.RateLimitingInterface
+	informers        map[schema.GroupVersionKind]*namespacedInformer
+	templateInformer *namespacedInformer
+	templateLister   kubananalisters.EventTriggeredJobLister
+	namespaceAccess  *namespaceAccess
+	resourceStatus   map[string]map[string]string // Tracks resource statuses
+	resourceStatusMu sync.Mutex                   // Guards resourceStatus across informer handlers
+	options          Options
+	limiter          *triggerLimiter
+	debouncer        *debouncer
+	suspended        *suspendQueue
+	history          *triggerHistory
+	dropped          *droppedTriggers
+	standby          atomic.Bool
+	replaySince      time.Time
+	health           workerHealth
+	recorder         record.EventRecorder
 }
 
-// NewStatusController creates a new StatusController
-func NewStatusController(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface) *StatusController {
+// NewStatusController creates a new StatusController with default options
+func NewStatusController(
+	kubeClient kubernetes.Interface,
+	kubananaClient versioned.Interface,
+	dynamicClient dynamic.Interface) *StatusController {
+
+	return NewStatusControllerWithOptions(kubeClient, kubananaClient, dynamicClient, Options{})
+}
+
+// NewStatusControllerWithOptions creates a new StatusController with the given options
+func NewStatusControllerWithOptions(
+	kubeClient kubernetes.Interface,
+	kubananaClient versioned.Interface,
+	dynamicClient dynamic.Interface,
+	options Options) *StatusController {
+
+	templateInformer, templateLister := newTemplateInformer(kubananaClient, watchNamespaces(options), options.StatusResyncPeriod)
+
+	recorder := newEventRecorder(kubeClient)
 	controller := &StatusController{
-		kubeClient:     kubeClient,
-		dynamicClient:  dynamicClient,
-		workqueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		informers:      make(map[schema.GroupVersionKind]cache.SharedIndexInformer),
+		kubeClient:       kubeClient,
+		kubananaClient:   kubananaClient,
+		dynamicClient:    dynamicClient,
+		templateInformer: templateInformer,
+		templateLister:   templateLister,
+		namespaceAccess:  newNamespaceAccess(kubeClient, watchNamespaces(options)),
+		workqueue: workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(),
+			workqueue.RateLimitingQueueConfig{Name: "status"}),
+		informers:      make(map[schema.GroupVersionKind]*namespacedInformer),
 		resourceStatus: make(map[string]map[string]string),
+		options:        options,
+		limiter:        newTriggerLimiter(),
+		debouncer:      newDebouncer(statusTrigger),
+		suspended:      newSuspendQueue(),
+		history:        newTriggerHistory(kubananaClient, options.TriggerHistoryLimit),
+		dropped:        newDroppedTriggers(kubananaClient, recorder),
+		recorder:       recorder,
 	}
 
+	templateInformer.AddEventHandlerWithResyncPeriod(
+		suspendedConditionHandler(kubananaClient, &controller.standby), options.StatusResyncPeriod)
+
 	// Load initial templates if not in a test environment
 	_, isTest := os.LookupEnv("TEST_MODE")
 	if !isTest {
@@ -52,34 +106,25 @@ func NewStatusController(kubeClient kubernetes.Interface, dynamicClient dynamic.
 	return controller
 }
 
-// refreshTemplates loads all EventTriggeredJobs with StatusSelector
+// refreshTemplates sets up informers for the resource kinds of all EventTriggeredJobs with a StatusSelector
 func (c *StatusController) refreshTemplates() {
-	// Skip for testing when kubeClient might not be fully initialized
-	if c.kubeClient == nil {
-		klog.Warningf("Skipping refresh templates, kubeClient is nil")
-		return
-	}
-
-	templateList := &v1alpha1.EventTriggeredJobList{}
-	err := c.kubeClient.CoreV1().RESTClient().
-		Get().
-		AbsPath("/apis/kubanana.roshanbhatia.com/v1alpha1/eventtriggeredjobs").
-		Do(context.Background()).
-		Into(templateList)
-
-	if err != nil {
-		klog.Errorf("Failed to get templates: %v", err)
-		return
-	}
-
 	// Filter templates that have a StatusSelector
-	c.templates = []v1alpha1.EventTriggeredJob{}
+	templates := 0
 	watchedKinds := make(map[string]bool)
 
-	for _, template := range templateList.Items {
-		if template.Spec.StatusSelector != nil {
-			c.templates = append(c.templates, template)
-			watchedKinds[template.Spec.StatusSelector.ResourceKind] = true
+	for _, namespace := range watchNamespaces(c.options) {
+		templateList, err := c.kubananaClient.KubananaV1alpha1().EventTriggeredJobs(namespace).
+			List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			klog.ErrorS(err, "Failed to get templates", "namespace", namespace)
+			return
+		}
+
+		for _, template := range templateList.Items {
+			if template.Spec.StatusSelector != nil {
+				templates++
+				watchedKinds[template.Spec.StatusSelector.ResourceKind] = true
+			}
 		}
 	}
 
@@ -88,7 +133,7 @@ func (c *StatusController) refreshTemplates() {
 		c.setupInformerForKind(kind)
 	}
 
-	klog.Infof("Loaded %d status-based templates", len(c.templates))
+	klog.InfoS("Loaded status-based templates", "templates", templates)
 }
 
 // setupInformerForKind creates an informer for a specific resource kind
@@ -154,24 +199,27 @@ func (c *StatusController) setupInformerForKind(kind string) {
 		return
 	}
 
-	// Create a dynamic list/watch for the resource
-	listFunc := func(options metav1.ListOptions) (runtime.Object, error) {
-		return c.dynamicClient.Resource(gvr).Namespace("").List(context.Background(), options)
-	}
+	// Watch the resource in each watched namespace, or in all namespaces if they aren't restricted
+	informer := newNamespacedInformer(watchNamespaces(c.options), func(namespace string) cache.SharedIndexInformer {
+		// Create a dynamic list/watch for the resource
+		listFunc := func(options metav1.ListOptions) (runtime.Object, error) {
+			return c.dynamicClient.Resource(gvr).Namespace(namespace).List(context.Background(), options)
+		}
 
-	watchFunc := func(options metav1.ListOptions) (watch.Interface, error) {
-		return c.dynamicClient.Resource(gvr).Namespace("").Watch(context.Background(), options)
-	}
+		watchFunc := func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.dynamicClient.Resource(gvr).Namespace(namespace).Watch(context.Background(), options)
+		}
 
-	informer := cache.NewSharedIndexInformer(
-		&cache.ListWatch{
-			ListFunc:  listFunc,
-			WatchFunc: watchFunc,
-		},
-		&unstructured.Unstructured{},
-		0,
-		cache.Indexers{},
-	)
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc:  listFunc,
+				WatchFunc: watchFunc,
+			},
+			&unstructured.Unstructured{},
+			c.options.StatusResyncPeriod,
+			cache.Indexers{},
+		)
+	})
 
 	// Add event handlers
 	// Using AddEventHandlerWithResyncPeriod which doesn't return a value in our version
@@ -181,41 +229,101 @@ func (c *StatusController) setupInformerForKind(kind string) {
 			c.handleObject(new)
 		},
 		DeleteFunc: c.handleObject,
-	}, 0)
+	}, c.options.StatusResyncPeriod)
 
 	c.informers[gvk] = informer
-	klog.Infof("Set up informer for resource kind: %s", kind)
+	metrics.RegisterInformer(gvk.String(), informer)
+	klog.InfoS("Set up informer for resource kind", "resourceKind", kind)
 }
 
-// Run starts the controller
+// Run starts the controller and processes triggers until stopCh is closed
 func (c *StatusController) Run(workers int, stopCh <-chan struct{}) error {
-	defer c.workqueue.ShutDown()
+	if err := c.Start(stopCh); err != nil {
+		c.workqueue.ShutDown()
+		return err
+	}
 
-	klog.Info("Starting status controller")
+	c.RunWorkers(workers, stopCh)
+	return nil
+}
+
+// Start runs the informers and waits for their caches to sync. Until RunWorkers is called the
+// controller is a standby that keeps its caches and status tracking warm without processing
+// any triggers.
+func (c *StatusController) Start(stopCh <-chan struct{}) error {
+	c.standby.Store(true)
+	c.replaySince = time.Now()
+
+	klog.InfoS("Starting status controller")
 
 	// Start all the informers
+	go c.templateInformer.Run(stopCh)
+	go c.namespaceAccess.informer.Run(stopCh)
 	for gvk, informer := range c.informers {
-		klog.Infof("Starting informer for %s", gvk.String())
+		klog.InfoS("Starting informer", "gvk", gvk.String())
 		go informer.Run(stopCh)
 	}
 
+	if !cache.WaitForCacheSync(stopCh, c.templateInformer.HasSynced, c.namespaceAccess.informer.HasSynced) {
+		return fmt.Errorf("failed to wait for template and namespace caches to sync")
+	}
+
 	// Wait for all informers to sync
 	for gvk, informer := range c.informers {
-		klog.Infof("Waiting for informer for %s to sync", gvk.String())
+		klog.InfoS("Waiting for informer to sync", "gvk", gvk.String())
 		if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 			return fmt.Errorf("failed to wait for caches to sync")
 		}
 	}
 
-	klog.Info("Status controller synced and ready")
+	klog.InfoS("Status controller synced and ready")
+	return nil
+}
+
+// RunWorkers processes triggers until stopCh is closed
+func (c *StatusController) RunWorkers(workers int, stopCh <-chan struct{}) {
+	defer c.workqueue.ShutDown()
+
+	c.standby.Store(false)
+	klog.InfoS("Status controller processing triggers")
+	reconcileSuspendedConditions(c.kubananaClient, c.templateLister)
+	c.replayStandbyChanges()
 
 	for i := 0; i < workers; i++ {
-		go wait.Until(c.runWorker, time.Second, stopCh)
+		go c.health.run(fmt.Sprintf("status-%d", i), func() {
+			wait.Until(c.runWorker, time.Second, stopCh)
+		})
 	}
 
+	go c.health.run("status-resume", func() {
+		wait.Until(c.resumeQueuedTriggers, suspendResyncPeriod, stopCh)
+	})
+
 	<-stopCh
-	klog.Info("Shutting down status controller")
-	return nil
+	klog.InfoS("Shutting down status controller")
+	c.debouncer.stop()
+	c.history.flushAll()
+	c.dropped.flushAll()
+}
+
+// HasSynced checks if the informers of templates, namespaces and all watched resource kinds have synced their caches
+func (c *StatusController) HasSynced() bool {
+	if !c.templateInformer.HasSynced() || !c.namespaceAccess.informer.HasSynced() {
+		return false
+	}
+
+	for _, informer := range c.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Healthy returns an error if one of the controller's workers died
+func (c *StatusController) Healthy() error {
+	return c.health.check()
 }
 
 func (c *StatusController) runWorker() {
@@ -236,14 +344,14 @@ func (c *StatusController) processNextItem() bool {
 
 	key, ok := obj.(string)
 	if !ok {
-		klog.Errorf("Expected string in workqueue but got %#v", obj)
+		klog.ErrorS(nil, "Expected string in workqueue", "item", obj)
 		c.workqueue.Forget(obj)
 		return true
 	}
 
 	// Process the resource status change
 	if err := c.processStatusChange(key); err != nil {
-		klog.Errorf("Error processing status change for key %s: %v", key, err)
+		klog.ErrorS(err, "Error processing status change", "key", key)
 		c.workqueue.AddRateLimited(key)
 		return true
 	}
@@ -260,12 +368,12 @@ func (c *StatusController) handleObject(obj interface{}) {
 	if metaObj, ok = obj.(metav1.Object); !ok {
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
 		if !ok {
-			klog.Errorf("Error decoding object, invalid type")
+			klog.ErrorS(nil, "Error decoding object, invalid type", "type", fmt.Sprintf("%T", obj))
 			return
 		}
 		metaObj, ok = tombstone.Obj.(metav1.Object)
 		if !ok {
-			klog.Errorf("Error decoding object tombstone, invalid type")
+			klog.ErrorS(nil, "Error decoding object tombstone, invalid type", "type", fmt.Sprintf("%T", tombstone.Obj))
 			return
 		}
 	}
@@ -273,66 +381,103 @@ func (c *StatusController) handleObject(obj interface{}) {
 	// Get the key to put in the queue
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if err != nil {
-		klog.Errorf("Failed to get key from object: %v", err)
+		klog.ErrorS(err, "Failed to get key from object")
 		return
 	}
 
 	// Get the current object from the unstructured data
 	unstructuredObj, ok := obj.(*unstructured.Unstructured)
 	if !ok {
-		klog.Errorf("Expected unstructured object, got %T", obj)
+		klog.ErrorS(nil, "Expected unstructured object", "type", fmt.Sprintf("%T", obj))
 		return
 	}
 
 	// Extract conditions from status
-	conditions, found, err := unstructured.NestedSlice(unstructuredObj.Object, "status", "conditions")
-	if err != nil || !found {
+	if _, found, err := unstructured.NestedSlice(unstructuredObj.Object, "status", "conditions"); err != nil || !found {
 		// No conditions found, but not an error - just means this object might not have conditions
 		// We'll still process it to handle resources that have just started reporting conditions
-		klog.V(5).Infof("No conditions found for %s/%s", metaObj.GetNamespace(), metaObj.GetName())
-		c.workqueue.Add(key)
+		klog.V(5).InfoS("No conditions found", "object", klog.KObj(metaObj))
+		c.enqueue(key)
 		return
 	}
 
 	// Parse conditions and check if they've changed
 	changed := false
-	currentStatus := make(map[string]string)
-
-	// Try to extract conditions - different resources may store conditions differently
-	for _, cond := range conditions {
-		condition, ok := cond.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		condType, typeFound := condition["type"].(string)
-		condStatus, statusFound := condition["status"].(string)
-
-		if typeFound && statusFound {
-			currentStatus[condType] = condStatus
-		}
-	}
+	currentStatus := StatusConditions(unstructuredObj.Object)
 
 	// Only process if we have actual conditions
 	if len(currentStatus) == 0 {
-		klog.V(5).Infof("No valid conditions extracted for %s/%s", metaObj.GetNamespace(), metaObj.GetName())
+		klog.V(5).InfoS("No valid conditions extracted", "object", klog.KObj(metaObj))
 		return
 	}
 
 	// Check if status has changed
+	c.resourceStatusMu.Lock()
 	previousStatus, exists := c.resourceStatus[key]
-	if !exists || !statusEqual(previousStatus, currentStatus) {
+	if !exists || StatusChanged(previousStatus, currentStatus) {
 		changed = true
 		c.resourceStatus[key] = currentStatus
-		klog.V(4).Infof("Status changed for %s/%s: %v", metaObj.GetNamespace(), metaObj.GetName(), currentStatus)
+	}
+	c.resourceStatusMu.Unlock()
+
+	if changed {
+		klog.V(4).InfoS("Status changed", "object", klog.KObj(metaObj), "conditions", currentStatus)
 	}
 
 	if changed {
 		// Add to workqueue for processing
-		c.workqueue.Add(key)
+		c.enqueue(key)
 	}
 }
 
+// enqueue adds the key to the workqueue unless the controller is a standby. Standbys still
+// track resource statuses, so only the statuses replayStandbyChanges picks up are replayed
+// once they become leader.
+func (c *StatusController) enqueue(key string) {
+	if c.standby.Load() {
+		return
+	}
+
+	metrics.EventReceived(statusTrigger)
+	c.workqueue.Add(key)
+}
+
+// ReplaySince sets when the previous leader stopped handling triggers, e.g. its last lease renewal.
+// Statuses that changed on standby after that are replayed once RunWorkers is called. It defaults
+// to when the controller was started.
+func (c *StatusController) ReplaySince(since time.Time) {
+	c.replaySince = since
+}
+
+// replayStandbyChanges queues the cached resources with a condition that transitioned after the
+// replay time. Standbys don't queue status changes, so this covers the ones that happened while no
+// replica was handling them.
+func (c *StatusController) replayStandbyChanges() {
+	if c.replaySince.IsZero() {
+		return
+	}
+
+	replayed := 0
+	for _, informer := range c.informers {
+		for _, key := range informer.ListKeys() {
+			item, exists, err := informer.GetByKey(key)
+			if err != nil || !exists {
+				continue
+			}
+			obj, ok := item.(*unstructured.Unstructured)
+			if !ok || !conditionsChangedSince(obj.Object, c.replaySince) {
+				continue
+			}
+
+			metrics.EventReceived(statusTrigger)
+			c.workqueue.Add(key)
+			replayed++
+		}
+	}
+
+	klog.InfoS("Replayed status changes seen on standby", "count", replayed, "since", c.replaySince)
+}
+
 // statusEqual checks if two status maps are equal
 func statusEqual(old, new map[string]string) bool {
 	if len(old) != len(new) {
@@ -362,7 +507,7 @@ func (c *StatusController) processStatusChange(key string) error {
 	// Find the right informer based on the resource kind
 	// This is simplified - you'd need to identify the correct GVK
 	for gvk, informer := range c.informers {
-		if item, exists, err := informer.GetStore().GetByKey(key); err == nil && exists {
+		if item, exists, err := informer.GetByKey(key); err == nil && exists {
 			var ok bool
 			if obj, ok = item.(runtime.Object); ok {
 				resourceKind = gvk.Kind
@@ -373,7 +518,9 @@ func (c *StatusController) processStatusChange(key string) error {
 
 	if obj == nil {
 		// Object may have been deleted, clean up our status tracking
+		c.resourceStatusMu.Lock()
 		delete(c.resourceStatus, key)
+		c.resourceStatusMu.Unlock()
 		return nil
 	}
 
@@ -385,7 +532,7 @@ func (c *StatusController) processStatusChange(key string) error {
 
 	// For testing, skip the template retrieval
 	if os.Getenv("TEST_MODE") == "true" {
-		klog.V(4).Infof("Running in test mode, skipping template retrieval")
+		klog.V(4).InfoS("Running in test mode, skipping template retrieval")
 		return nil
 	}
 
@@ -399,35 +546,42 @@ func (c *StatusController) processStatusChange(key string) error {
 		labels := objMeta.GetLabels() // Will use this with selectors
 	*/
 
-	// Get status conditions
-	conditions, found, err := unstructured.NestedSlice(unstructuredObj, "status", "conditions")
-	if err != nil {
-		return fmt.Errorf("failed to get conditions: %w", err)
-	}
-
-	if !found || len(conditions) == 0 {
-		// No conditions found, nothing to process
+	// Get status conditions, there's nothing to match without them
+	conditionMap := StatusConditions(unstructuredObj)
+	if len(conditionMap) == 0 {
 		return nil
 	}
 
-	// Process conditions
-	conditionMap := make(map[string]string)
-	for _, cond := range conditions {
-		condition, ok := cond.(map[string]interface{})
-		if !ok {
-			continue
+	// Owner references are used to batch debounced triggers per owner
+	var ownerRefs []metav1.OwnerReference
+	objMeta, err := meta.Accessor(obj)
+	if err == nil {
+		ownerRefs = objMeta.GetOwnerReferences()
+	}
+	var origin *triggerOrigin
+	resolveOrigin := func(ctx context.Context) triggerOrigin {
+		if origin == nil {
+			resolved := resolveTriggerOrigin(ctx, c.kubeClient, c.options, objMeta)
+			origin = &resolved
 		}
+		return *origin
+	}
 
-		condType, typeFound := condition["type"].(string)
-		condStatus, statusFound := condition["status"].(string)
+	resourceKey := triggerResource(resourceKind, namespace, name)
+	logger := klog.LoggerWithValues(klog.Background(), logKeyTrigger, statusTrigger, logKeyResource, resourceKey)
 
-		if typeFound && statusFound {
-			conditionMap[condType] = condStatus
-		}
+	ctx, span := startSpan(context.Background(), spanReceiveStatusChange, triggerAttributes(statusTrigger, resourceKey)...)
+	defer span.End()
+
+	templates, err := listTemplates(c.templateLister)
+	if err != nil {
+		err = fmt.Errorf("failed to list templates: %w", err)
+		endSpan(span, err)
+		return err
 	}
 
 	// Check each template for a match
-	for _, template := range c.templates {
+	for _, template := range templates {
 		// Skip templates without a StatusSelector
 		if template.Spec.StatusSelector == nil {
 			continue
@@ -438,60 +592,283 @@ func (c *StatusController) processStatusChange(key string) error {
 			continue
 		}
 
-		// Check name pattern if specified
-		if template.Spec.StatusSelector.NamePattern != "" {
-			if !matchNamePattern(template.Spec.StatusSelector.NamePattern, name) {
-				continue
-			}
-		}
+		c.evaluateTemplate(ctx, logger, template, resourceKind, namespace, name, ownerRefs, conditionMap, resolveOrigin)
+	}
 
-		// Check namespace pattern if specified
-		if template.Spec.StatusSelector.NamespacePattern != "" {
-			if !matchNamePattern(template.Spec.StatusSelector.NamespacePattern, namespace) {
-				continue
-			}
+	return nil
+}
+
+// evaluateTemplate runs template if the resource's conditions match it
+func (c *StatusController) evaluateTemplate(
+	ctx context.Context,
+	logger klog.Logger,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	ownerRefs []metav1.OwnerReference,
+	conditionMap map[string]string,
+	resolveOrigin func(context.Context) triggerOrigin) {
+
+	ctx, span := startSpan(ctx, spanEvaluateTemplate, templateAttributes(template)...)
+	defer span.End()
+	metrics.TemplateEvaluated(template.Namespace, template.Name, statusTrigger)
+
+	// Check the name and namespace patterns and the conditions
+	if result := MatchStatus(template, resourceKind, namespace, name, conditionMap); !result.Matched() {
+		logger.V(4).Info("Skipping template", logKeyTemplate, klog.KObj(template), "reason", result.Reason())
+		return
+	}
+
+	// Templates only observe other namespaces if their own namespace grants it
+	if !c.namespaceAccess.canObserve(template, namespace) {
+		logger.V(4).Info("Skipping template, namespace can't be observed from the template's namespace",
+			logKeyTemplate, klog.KObj(template))
+		return
+	}
+
+	if !checkTemplateValid(c.recorder, template) {
+		return
+	}
+
+	ctx = withTrigger(ctx, template, statusTrigger, triggerResource(resourceKind, namespace, name))
+
+	// Objects Kubanana created itself only trigger templates that allow it
+	origin := resolveOrigin(ctx)
+	if allowed, reason := checkSelfTrigger(template, origin); !allowed {
+		if reason != "" {
+			recordDroppedTrigger(ctx, c.dropped, template, statusTrigger, reason)
+			c.auditTrigger(ctx, template, resourceKind, namespace, name, conditionMap, v1alpha1.DroppedTriggerOutcome, "", reason)
 		}
+		return
+	}
+
+	// Template matched, create a job
+	klog.FromContext(ctx).Info("Template matched status conditions, creating job")
+	span.SetAttributes(attribute.Bool("kubanana.matched", true))
+	metrics.TemplateMatched(template.Namespace, template.Name, statusTrigger)
+
+	c.runTemplate(ctx, template, resourceKind, namespace, name, ownerRefs, conditionMap, origin)
+}
+
+// runTemplate runs a template that matched the status change unless the template is suspended
+func (c *StatusController) runTemplate(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	ownerRefs []metav1.OwnerReference,
+	conditions map[string]string,
+	origin triggerOrigin) {
+
+	run := func(latest *v1alpha1.EventTriggeredJob) {
+		c.runTemplate(ctx, latest, resourceKind, namespace, name, ownerRefs, conditions, origin)
+	}
+
+	if checkSuspended(ctx, c.dropped, c.suspended, template, statusTrigger, run) {
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, suspendedOutcome(template), "", dropReasonSuspended)
+		return
+	}
+
+	// Collect bursty triggers into a single job if the template is debounced
+	if template.Spec.Debounce != nil {
+		c.debounceTrigger(ctx, template, resourceKind, namespace, name, ownerRefs, conditions, origin)
+		return
+	}
+
+	c.triggerJob(ctx, template, resourceKind, namespace, name, conditions, origin, nil)
+}
+
+// resumeQueuedTriggers runs the queued triggers of templates that were resumed
+func (c *StatusController) resumeQueuedTriggers() {
+	c.suspended.resume(func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return fetchTemplate(context.Background(), c.kubananaClient, namespace, name)
+	})
+}
+
+// triggerJob enforces the template's guardrails and creates a job for the status match.
+// batch holds the coalesced triggers if the job is created for a debounced batch.
+func (c *StatusController) triggerJob(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	conditions map[string]string,
+	origin triggerOrigin,
+	batch []debouncedTrigger) {
+
+	// Enforce the template's rate limits before touching any existing jobs. The slot reserved for the
+	// trigger is released again unless a job is created.
+	resourceKey := triggerResource(resourceKind, namespace, name)
+	release, allowed, reason := c.limiter.allow(template, resourceKey)
+	if !allowed {
+		recordDroppedTrigger(ctx, c.dropped, template, statusTrigger, reason)
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.DroppedTriggerOutcome, "", reason)
+		return
+	}
+
+	// Enforce the template's concurrency policy against previously created jobs
+	proceed, err := applyConcurrencyPolicy(ctx, c.kubeClient, c.options, template,
+		jobNamespace(template, namespace, statusJobNamespacePolicy),
+		corev1.ObjectReference{Kind: resourceKind, Namespace: namespace, Name: name})
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to apply concurrency policy")
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.JobFailedTriggerOutcome, "", err.Error())
+		release()
+		return
+	}
+	if !proceed {
+		recordDroppedTrigger(ctx, c.dropped, template, statusTrigger, dropReasonConcurrencyForbidden)
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.DroppedTriggerOutcome, "",
+			dropReasonConcurrencyForbidden)
+		release()
+		return
+	}
+
+	// Create job based on the template
+	involved := c.resourceReference(resourceKind, namespace, name)
+	job, err := c.createJobFromTemplate(ctx, template, resourceKind, namespace, name, conditions, origin, batch)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to create job from template")
+		metrics.JobCreationFailed(template.Namespace, template.Name, statusTrigger)
+		recordTriggerEvent(c.recorder, c.options, template, involved, corev1.EventTypeWarning, reasonJobCreationFailed,
+			"Failed to create job for %s %s/%s: %v", resourceKind, namespace, name, err)
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.JobFailedTriggerOutcome, "", err.Error())
+		release()
+		return
+	}
+	if isDryRun(c.options, template) {
+		recordDryRunJob(ctx, c.kubananaClient, c.recorder, c.options, template, involved, job, statusTrigger)
+		c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.JobDryRunTriggerOutcome,
+			dryRunJobName(job), "")
+		release()
+		return
+	}
+	metrics.JobCreated(template.Namespace, template.Name, statusTrigger)
+	recordCreatedJob(ctx, c.kubananaClient, template)
+	recordTriggerEvent(c.recorder, c.options, template, involved, corev1.EventTypeNormal, reasonJobTriggered,
+		"Created job %s/%s for %s %s/%s", job.Namespace, job.Name, resourceKind, namespace, name)
+	c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.JobCreatedTriggerOutcome, jobRef(job), "")
+}
 
-		// TODO: Implement label selector matching if needed
-		if template.Spec.StatusSelector.LabelSelector != nil {
-			// Skip for now - in a real implementation you'd check label selectors
-			// This requires proper label selector matching
-			klog.V(4).Infof("Label selector matching not implemented yet")
+// auditTrigger records the outcome of the status match triggering the template
+func (c *StatusController) auditTrigger(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	conditions map[string]string,
+	outcome v1alpha1.TriggerOutcome,
+	job, reason string) {
+
+	involved := c.resourceReference(resourceKind, namespace, name)
+	match := MatchStatus(template, resourceKind, namespace, name, conditions)
+	record := newTriggerRecord(statusTrigger, "", involved, match, outcome, job, reason)
+	writeTriggerRecord(ctx, c.history, c.options, template, record)
+}
+
+// resourceReference builds a reference to a watched resource for events about it. The UID and resourceVersion are
+// looked up in the informer cache so the events show up when describing the resource.
+func (c *StatusController) resourceReference(resourceKind, namespace, name string) *corev1.ObjectReference {
+	ref := &corev1.ObjectReference{
+		Kind:      resourceKind,
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	for gvk, informer := range c.informers {
+		if gvk.Kind != resourceKind {
+			continue
 		}
 
-		// Check if conditions match
-		conditionsMatch := true
-		for _, requiredCond := range template.Spec.StatusSelector.Conditions {
-			actualStatus, exists := conditionMap[requiredCond.Type]
-			if !exists || actualStatus != requiredCond.Status {
-				conditionsMatch = false
-				break
+		ref.APIVersion = gvk.GroupVersion().String()
+		key := name
+		if namespace != "" {
+			key = namespace + "/" + name
+		}
+		if item, exists, err := informer.GetByKey(key); err == nil && exists {
+			if objMeta, err := meta.Accessor(item); err == nil {
+				ref.UID = objMeta.GetUID()
+				ref.ResourceVersion = objMeta.GetResourceVersion()
 			}
 		}
+		break
+	}
 
-		if !conditionsMatch {
-			continue
-		}
+	return ref
+}
+
+// debounceTrigger collects the status match into the template's current debounce batch
+func (c *StatusController) debounceTrigger(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	ownerRefs []metav1.OwnerReference,
+	conditions map[string]string,
+	origin triggerOrigin) {
 
-		// Template matched, create a job
-		klog.Infof("Template %s matched status conditions for %s/%s, creating job",
-			template.Name, resourceKind, name)
+	resourceKey := triggerResource(resourceKind, namespace, name)
 
-		// Create job based on the template
-		if err := c.createJobFromTemplate(&template, resourceKind, namespace, name, conditionMap); err != nil {
-			klog.Errorf("Failed to create job from template %s: %v", template.Name, err)
-			continue
-		}
+	ownerKey := resourceKey
+	if template.Spec.Debounce.Key == v1alpha1.OwnerDebounceKey {
+		ownerKey = resolveOwnerKey(ctx, c.kubeClient, namespace, ownerRefs, resourceKey)
 	}
 
-	return nil
+	trigger := debouncedTrigger{
+		ResourceKind:      resourceKind,
+		ResourceName:      name,
+		ResourceNamespace: namespace,
+		TriggerType:       "status",
+		Time:              metav1.Now(),
+	}
+
+	c.auditTrigger(ctx, template, resourceKind, namespace, name, conditions, v1alpha1.DebouncedTriggerOutcome, "", "")
+
+	// The batch outlives this call, so hand it a copy of the template
+	template = template.DeepCopy()
+
+	c.debouncer.add(debounceKey(template, resourceKey, ownerKey), template.Spec.Debounce.Window.Duration, trigger,
+		func(triggers []debouncedTrigger) {
+			klog.FromContext(ctx).Info("Debounce window closed, creating job", "triggers", len(triggers))
+			metrics.TriggerSkipped(template.Namespace, template.Name, statusTrigger, dropReasonDebounced, len(triggers)-1)
+			c.triggerJob(ctx, template, resourceKind, namespace, name, conditions, origin, triggers)
+		})
 }
 
 // createJobFromTemplate creates a job based on a template when status conditions match
 func (c *StatusController) createJobFromTemplate(
+	ctx context.Context,
+	template *v1alpha1.EventTriggeredJob,
+	resourceKind, namespace, name string,
+	conditions map[string]string,
+	origin triggerOrigin,
+	batch []debouncedTrigger) (_ *batchv1.Job, err error) {
+
+	ctx, span := startSpan(ctx, spanCreateJob)
+	defer func() { endSpan(span, err) }()
+
+	job := newStatusJob(template, resourceKind, namespace, name, conditions, origin, batch, c.options)
+
+	// Let the job's workload continue the trace of its trigger
+	injectTraceParent(ctx, job)
+
+	// Create the job in the namespace chosen by the template's JobNamespacePolicy, as the template's service account
+	createdJob, err := createJob(ctx, c.kubeClient, c.options, template, job)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("kubanana.job", jobRef(createdJob)))
+
+	// Dry-run jobs are logged once their trigger is recorded
+	if !isDryRun(c.options, template) {
+		klog.FromContext(ctx).Info("Created job for status match", logKeyJob, klog.KObj(createdJob))
+	}
+	return createdJob, nil
+}
+
+// newStatusJob renders the job a template runs for a resource whose conditions matched, without creating it
+func newStatusJob(
 	template *v1alpha1.EventTriggeredJob,
 	resourceKind, namespace, name string,
-	conditions map[string]string) error {
+	conditions map[string]string,
+	origin triggerOrigin,
+	batch []debouncedTrigger,
+	options Options) *batchv1.Job {
 
 	// Create job name based on template name
 	jobName := fmt.Sprintf("%s-%s-%s",
@@ -501,10 +878,11 @@ func (c *StatusController) createJobFromTemplate(
 
 	// Create labels for the job
 	labels := map[string]string{
-		"kubanana-template":      template.Name,
-		"kubanana-resource-kind": resourceKind,
-		"kubanana-resource-name": name,
-		"kubanana-trigger-type":  "status",
+		TemplateLabel:          template.Name,
+		ResourceKindLabel:      resourceKind,
+		ResourceNamespaceLabel: namespace,
+		ResourceNameLabel:      name,
+		TriggerTypeLabel:       "status",
 	}
 
 	// Get resource condition types and statuses for labels
@@ -520,20 +898,10 @@ func (c *StatusController) createJobFromTemplate(
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: jobName + "-",
-			// Use template namespace for the job, not resource namespace
-			Namespace: template.Namespace,
-			Labels:    labels,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion: "kubanana.roshanbhatia.com/v1alpha1",
-					Kind:       "EventTriggeredJob",
-					Name:       template.Name,
-					UID:        template.UID,
-					Controller: &[]bool{true}[0],
-				},
-			},
+			Namespace:    jobNamespace(template, namespace, statusJobNamespacePolicy),
+			Labels:       labels,
 		},
-		Spec: template.Spec.JobTemplate.Spec,
+		Spec: *template.Spec.JobTemplate.Spec.DeepCopy(),
 	}
 
 	// Apply variable substitution to the job spec
@@ -551,7 +919,8 @@ func (c *StatusController) createJobFromTemplate(
 		}
 
 		// Add condition environment variables
-		for condType, condStatus := range conditions {
+		for _, condType := range conditionTypes(conditions) {
+			condStatus := conditions[condType]
 			envVarName := fmt.Sprintf("STATUS_%s", strings.ReplaceAll(condType, "-", "_"))
 			envVars = append(envVars, corev1.EnvVar{Name: envVarName, Value: condStatus})
 		}
@@ -574,14 +943,19 @@ func (c *StatusController) createJobFromTemplate(
 		}
 	}
 
-	// Create the job in the template's namespace, not the resource's namespace
-	createdJob, err := c.kubeClient.BatchV1().Jobs(template.Namespace).Create(context.Background(), job, metav1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to create job: %w", err)
-	}
+	// Let the template own the job, or label it if the job lives in another namespace
+	setJobOwner(job, template)
 
-	klog.Infof("Created job %s/%s for status match", createdJob.Namespace, createdJob.Name)
-	return nil
+	// Expose the coalesced triggers of a debounced batch
+	addTriggerBatchEnv(job, batch)
+
+	// Mark the job and its pods so they don't trigger templates in an endless loop
+	applyTriggerOrigin(job, template, origin)
+
+	// Fill in controller-wide defaults such as the job TTL
+	applyJobDefaults(job, options)
+
+	return job
 }
 
 // substituteStatusVariables substitutes variables in a string for status-triggered jobs
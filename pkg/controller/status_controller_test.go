@@ -2,19 +2,26 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
 )
 
 func TestNewStatusController(t *testing.T) {
@@ -24,7 +31,7 @@ func TestNewStatusController(t *testing.T) {
 	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
 
 	// Create a new status controller
-	controller := NewStatusController(kubeClient, dynamicClient)
+	controller := NewStatusController(kubeClient, kubananafake.NewSimpleClientset(), dynamicClient)
 
 	// Check if the controller is properly initialized
 	if controller.kubeClient != kubeClient {
@@ -60,7 +67,7 @@ func TestCreateJobForStatusChange(t *testing.T) {
 		kubeClient:     kubeClient,
 		dynamicClient:  dynamicClient,
 		workqueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		informers:      make(map[schema.GroupVersionKind]cache.SharedIndexInformer),
+		informers:      make(map[schema.GroupVersionKind]*namespacedInformer),
 		resourceStatus: make(map[string]map[string]string),
 	}
 
@@ -117,11 +124,8 @@ func TestCreateJobForStatusChange(t *testing.T) {
 	// Store a resource status
 	controller.resourceStatus["default/test-pod"] = conditions
 
-	// Store the template
-	controller.templates = []v1alpha1.EventTriggeredJob{*template}
-
 	// Test the job creation method directly
-	err := controller.createJobFromTemplate(template, resourceKind, namespace, name, conditions)
+	_, err := controller.createJobFromTemplate(context.Background(), template, resourceKind, namespace, name, conditions, triggerOrigin{}, nil)
 	if err != nil {
 		t.Fatalf("Failed to create job: %v", err)
 	}
@@ -302,6 +306,123 @@ func TestStatusChangeDetection(t *testing.T) {
 	}
 }
 
+// Test that standbys track status changes without queueing them
+func TestHandleObjectStandby(t *testing.T) {
+	controller := &StatusController{
+		workqueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		resourceStatus: make(map[string]map[string]string),
+	}
+	controller.standby.Store(true)
+
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace("default")
+	pod.SetName("test-pod")
+	_ = unstructured.SetNestedSlice(pod.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions")
+
+	controller.handleObject(pod)
+
+	if controller.workqueue.Len() != 0 {
+		t.Errorf("Expected standby not to queue status changes, got %d items", controller.workqueue.Len())
+	}
+	if controller.resourceStatus["default/test-pod"]["Ready"] != "True" {
+		t.Errorf("Expected standby to track the resource status")
+	}
+
+	// The same status seen again after becoming leader isn't a change
+	controller.standby.Store(false)
+	controller.handleObject(pod)
+	if controller.workqueue.Len() != 0 {
+		t.Errorf("Expected unchanged status not to be queued, got %d items", controller.workqueue.Len())
+	}
+}
+
+// Test that informers watching separate namespaces can track statuses concurrently (run with -race)
+func TestHandleObjectConcurrentNamespaces(t *testing.T) {
+	controller := &StatusController{
+		workqueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		resourceStatus: make(map[string]map[string]string),
+	}
+	defer controller.workqueue.ShutDown()
+
+	var wg sync.WaitGroup
+	for _, namespace := range []string{"team-a", "team-b"} {
+		wg.Add(1)
+		go func(namespace string) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				pod := &unstructured.Unstructured{}
+				pod.SetAPIVersion("v1")
+				pod.SetKind("Pod")
+				pod.SetNamespace(namespace)
+				pod.SetName(fmt.Sprintf("pod-%d", i%10))
+				_ = unstructured.SetNestedSlice(pod.Object, []interface{}{
+					map[string]interface{}{"type": "Ready", "status": fmt.Sprintf("%t", i%2 == 0)},
+				}, "status", "conditions")
+
+				controller.handleObject(pod)
+				if i%3 == 0 {
+					// Without informers the object looks deleted, so its status is dropped
+					_ = controller.processStatusChange(namespace + "/" + pod.GetName())
+				}
+			}
+		}(namespace)
+	}
+	wg.Wait()
+
+	for key := range controller.resourceStatus {
+		if _, _, err := cache.SplitMetaNamespaceKey(key); err != nil {
+			t.Errorf("Unexpected resource status key %s", key)
+		}
+	}
+	if len(controller.resourceStatus) == 0 || len(controller.resourceStatus) > 20 {
+		t.Errorf("Expected statuses for both namespaces' pods, got %d", len(controller.resourceStatus))
+	}
+}
+
+func TestReplayStandbyChanges(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &unstructured.Unstructured{}, 0, cache.Indexers{})
+	controller := &StatusController{
+		workqueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		informers: map[schema.GroupVersionKind]*namespacedInformer{
+			{Version: "v1", Kind: "Pod"}: newNamespacedInformer([]string{metav1.NamespaceAll},
+				func(string) cache.SharedIndexInformer { return informer }),
+		},
+	}
+	defer controller.workqueue.ShutDown()
+
+	now := time.Now()
+	for name, transitioned := range map[string]time.Time{
+		"changed-on-standby": now,
+		"changed-before":     now.Add(-time.Hour),
+	} {
+		pod := &unstructured.Unstructured{}
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetNamespace("default")
+		pod.SetName(name)
+		_ = unstructured.SetNestedSlice(pod.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "lastTransitionTime": transitioned.UTC().Format(time.RFC3339)},
+		}, "status", "conditions")
+		if err := informer.GetStore().Add(pod); err != nil {
+			t.Fatalf("Failed to add pod to store: %v", err)
+		}
+	}
+
+	controller.ReplaySince(now.Add(-time.Minute))
+	controller.replayStandbyChanges()
+
+	if controller.workqueue.Len() != 1 {
+		t.Fatalf("Expected only the status changed on standby to be replayed, got %d items", controller.workqueue.Len())
+	}
+	if item, _ := controller.workqueue.Get(); item != "default/changed-on-standby" {
+		t.Errorf("Expected default/changed-on-standby to be replayed, got %v", item)
+	}
+}
+
 // Implement helper function for test
 func areStatusesEqual(old, new map[string]string) bool {
 	if len(old) != len(new) {
@@ -396,3 +517,77 @@ func TestEnhancedNamePatternMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusTriggerUpdatesTemplateStatus(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			StatusSelector: &v1alpha1.StatusSelector{
+				ResourceKind: "Pod",
+				Conditions:   []v1alpha1.StatusCondition{{Type: "Ready", Status: "True"}},
+			},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "busybox"}}},
+					},
+				},
+			},
+		},
+	}
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+	controller := NewStatusControllerWithOptions(fake.NewSimpleClientset(), kubananaClient,
+		dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), Options{})
+	controller.recorder = record.NewFakeRecorder(10)
+
+	controller.triggerJob(context.Background(), template, "Pod", "default", "web-1", map[string]string{"Ready": "True"},
+		triggerOrigin{}, nil)
+
+	// The Jobs and Last Triggered columns of kubectl get read these fields
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if updated.Status.JobsCreated != 1 || updated.Status.LastTriggeredTime == nil {
+		t.Errorf("Expected the created job to be counted with its trigger time, got %+v", updated.Status)
+	}
+}
+
+func TestEvaluateTemplateSkipsSelfTriggerBeforeMatching(t *testing.T) {
+	spans := recordSpans(t)
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "self-trigger-template", Namespace: "default"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			StatusSelector: &v1alpha1.StatusSelector{
+				ResourceKind: "Pod",
+				Conditions:   []v1alpha1.StatusCondition{{Type: "Ready", Status: "True"}},
+			},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "busybox"}}},
+					},
+				},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset()
+	controller := NewStatusControllerWithOptions(kubeClient, kubananafake.NewSimpleClientset(template),
+		dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), Options{})
+	controller.recorder = record.NewFakeRecorder(10)
+
+	// The pod belongs to a job the template created, so it must not count as a match
+	controller.evaluateTemplate(context.Background(), klog.Background(), template, "Pod", "default", "web-1", nil,
+		map[string]string{"Ready": "True"}, func(context.Context) triggerOrigin { return triggerOrigin{selfCreated: true} })
+
+	for _, span := range spans.Ended() {
+		for _, attr := range span.Attributes() {
+			if attr.Key == "kubanana.matched" {
+				t.Errorf("Expected the self-triggered status change not to be marked as matched in %s", span.Name())
+			}
+		}
+	}
+	if jobs, _ := kubeClient.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{}); len(jobs.Items) != 0 {
+		t.Errorf("Expected no job for a self-triggered status change, got %d", len(jobs.Items))
+	}
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	kubananalisters "github.com/roshbhatia/kubanana/pkg/client/listers/kubanana/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// suspendResyncPeriod is how often templates with a queued trigger are checked for being resumed
+	suspendResyncPeriod = 30 * time.Second
+
+	// dropReasonSuspended is recorded when a trigger arrives while the template is suspended
+	dropReasonSuspended = "Suspended"
+)
+
+// queuedTrigger is a trigger waiting for its suspended template to be resumed
+type queuedTrigger struct {
+	namespace string
+	name      string
+	seq       uint64
+	run       func(template *v1alpha1.EventTriggeredJob)
+}
+
+// suspendQueue keeps the most recent trigger of every suspended template using the QueueLatest policy
+type suspendQueue struct {
+	mu      sync.Mutex
+	pending map[string]queuedTrigger
+	seq     uint64
+}
+
+// newSuspendQueue creates a new suspendQueue
+func newSuspendQueue() *suspendQueue {
+	return &suspendQueue{
+		pending: make(map[string]queuedTrigger),
+	}
+}
+
+// queue replaces any previously queued trigger of the template with run
+func (q *suspendQueue) queue(template *v1alpha1.EventTriggeredJob, run func(template *v1alpha1.EventTriggeredJob)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	q.pending[template.Namespace+"/"+template.Name] = queuedTrigger{
+		namespace: template.Namespace,
+		name:      template.Name,
+		seq:       q.seq,
+		run:       run,
+	}
+}
+
+// resume runs the queued trigger of every template that is no longer suspended.
+// fetch returns the current version of a template.
+func (q *suspendQueue) resume(fetch func(namespace, name string) (*v1alpha1.EventTriggeredJob, error)) {
+	q.mu.Lock()
+	pending := make(map[string]queuedTrigger, len(q.pending))
+	for key, trigger := range q.pending {
+		pending[key] = trigger
+	}
+	q.mu.Unlock()
+
+	for key, trigger := range pending {
+		template, err := fetch(trigger.namespace, trigger.name)
+		if errors.IsNotFound(err) {
+			// The template was deleted while suspended, its trigger will never run
+			q.mu.Lock()
+			if current, exists := q.pending[key]; exists && current.seq == trigger.seq {
+				delete(q.pending, key)
+			}
+			q.mu.Unlock()
+			klog.InfoS("Suspended template was deleted, dropping its queued trigger", logKeyTemplate, key)
+			continue
+		}
+		if err != nil {
+			klog.ErrorS(err, "Failed to fetch suspended template", logKeyTemplate, key)
+			continue
+		}
+
+		if isSuspended(template) {
+			continue
+		}
+
+		// A newer trigger queued in the meantime is picked up on the next resync
+		q.mu.Lock()
+		current, exists := q.pending[key]
+		if !exists || current.seq != trigger.seq {
+			q.mu.Unlock()
+			continue
+		}
+		delete(q.pending, key)
+		q.mu.Unlock()
+
+		klog.InfoS("Template was resumed, running its queued trigger", logKeyTemplate, key)
+		trigger.run(template)
+	}
+}
+
+// isSuspended checks if job creation is paused for the template
+func isSuspended(template *v1alpha1.EventTriggeredJob) bool {
+	return template.Spec.Suspend != nil && *template.Spec.Suspend
+}
+
+// checkSuspended reports whether a trigger must not run because the template is suspended.
+// Such triggers are counted as dropped and, with the QueueLatest policy, queued to run once the
+// template is resumed.
+func checkSuspended(
+	ctx context.Context,
+	dropped *droppedTriggers,
+	queue *suspendQueue,
+	template *v1alpha1.EventTriggeredJob,
+	trigger string,
+	run func(template *v1alpha1.EventTriggeredJob)) bool {
+
+	if !isSuspended(template) {
+		return false
+	}
+
+	if template.Spec.SuspendPolicy == v1alpha1.QueueLatestSuspendPolicy {
+		queue.queue(template, run)
+		klog.FromContext(ctx).Info("Template is suspended, queued trigger until it is resumed")
+	}
+	recordDroppedTrigger(ctx, dropped, template, trigger, dropReasonSuspended)
+
+	return true
+}
+
+// suspendedConditionHandler reconciles the Suspended condition of templates as they're added or
+// changed, so suspending or resuming a template shows in its status without waiting for a trigger.
+// Standbys leave the status to the leader.
+func suspendedConditionHandler(kubananaClient versioned.Interface, standby *atomic.Bool) cache.ResourceEventHandlerFuncs {
+	reconcile := func(obj interface{}) {
+		template, ok := obj.(*v1alpha1.EventTriggeredJob)
+		if !ok || standby.Load() {
+			return
+		}
+		reconcileSuspendedCondition(context.Background(), kubananaClient, template)
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: reconcile,
+		UpdateFunc: func(old, new interface{}) {
+			reconcile(new)
+		},
+	}
+}
+
+// reconcileSuspendedConditions reconciles the Suspended condition of all cached templates, e.g. once
+// a standby becomes the leader and may have missed templates being suspended or resumed
+func reconcileSuspendedConditions(kubananaClient versioned.Interface, lister kubananalisters.EventTriggeredJobLister) {
+	templates, err := lister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list templates")
+		return
+	}
+
+	for _, template := range templates {
+		reconcileSuspendedCondition(context.Background(), kubananaClient, template)
+	}
+}
+
+// reconcileSuspendedCondition sets the template's Suspended condition to match spec.suspend. Templates
+// that were never suspended don't get the condition.
+func reconcileSuspendedCondition(ctx context.Context, kubananaClient versioned.Interface, template *v1alpha1.EventTriggeredJob) {
+	if !suspendedConditionStale(template) {
+		return
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := fetchTemplate(ctx, kubananaClient, template.Namespace, template.Name)
+		if err != nil {
+			return err
+		}
+
+		// Both controllers reconcile the condition, the other one may have updated it already
+		if !suspendedConditionStale(latest) {
+			return nil
+		}
+		meta.SetStatusCondition(&latest.Status.Conditions, suspendedCondition(latest, isSuspended(latest)))
+
+		_, err = kubananaClient.KubananaV1alpha1().EventTriggeredJobs(template.Namespace).
+			UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to update Suspended condition", logKeyTemplate, klog.KObj(template))
+	}
+}
+
+// suspendedConditionStale checks if the template's Suspended condition doesn't match spec.suspend
+func suspendedConditionStale(template *v1alpha1.EventTriggeredJob) bool {
+	condition := meta.FindStatusCondition(template.Status.Conditions, v1alpha1.ConditionSuspended)
+	if condition == nil {
+		return isSuspended(template)
+	}
+
+	return (condition.Status == metav1.ConditionTrue) != isSuspended(template)
+}
+
+// suspendedCondition builds the Suspended condition for the template
+func suspendedCondition(template *v1alpha1.EventTriggeredJob, suspended bool) metav1.Condition {
+	if suspended {
+		return metav1.Condition{
+			Type:               v1alpha1.ConditionSuspended,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: template.Generation,
+			Reason:             "SuspendedBySpec",
+			Message:            "Job creation is paused because spec.suspend is true",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               v1alpha1.ConditionSuspended,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: template.Generation,
+		Reason:             "Resumed",
+		Message:            "Job creation is active",
+	}
+}
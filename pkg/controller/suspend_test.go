@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newSuspendTestTemplate creates a template with the given suspend setting
+func newSuspendTestTemplate(name string, suspend bool) *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			Suspend:       &suspend,
+			SuspendPolicy: v1alpha1.QueueLatestSuspendPolicy,
+		},
+	}
+}
+
+func TestIsSuspended(t *testing.T) {
+	if isSuspended(&v1alpha1.EventTriggeredJob{}) {
+		t.Errorf("Expected template without suspend to be active")
+	}
+	if isSuspended(newSuspendTestTemplate("test-template", false)) {
+		t.Errorf("Expected template with suspend=false to be active")
+	}
+	if !isSuspended(newSuspendTestTemplate("test-template", true)) {
+		t.Errorf("Expected template with suspend=true to be suspended")
+	}
+}
+
+func TestSuspendQueueRunsLatestTriggerOnResume(t *testing.T) {
+	q := newSuspendQueue()
+	template := newSuspendTestTemplate("test-template", true)
+
+	var ran []string
+	for _, name := range []string{"pod-a", "pod-b"} {
+		resource := name
+		q.queue(template, func(latest *v1alpha1.EventTriggeredJob) {
+			ran = append(ran, resource)
+		})
+	}
+
+	current := template
+	fetch := func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return current, nil
+	}
+
+	// Still suspended, so nothing runs
+	q.resume(fetch)
+	if len(ran) != 0 {
+		t.Fatalf("Expected no trigger to run while suspended, got %v", ran)
+	}
+
+	current = newSuspendTestTemplate("test-template", false)
+	q.resume(fetch)
+	if len(ran) != 1 || ran[0] != "pod-b" {
+		t.Fatalf("Expected only the latest trigger to run, got %v", ran)
+	}
+
+	// The queued trigger only runs once
+	q.resume(fetch)
+	if len(ran) != 1 {
+		t.Errorf("Expected queued trigger to be removed after running, got %v", ran)
+	}
+}
+
+func TestSuspendQueueKeepsTriggerOnFetchError(t *testing.T) {
+	q := newSuspendQueue()
+	template := newSuspendTestTemplate("test-template", true)
+
+	runs := 0
+	q.queue(template, func(latest *v1alpha1.EventTriggeredJob) { runs++ })
+
+	q.resume(func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return nil, fmt.Errorf("not available")
+	})
+	if runs != 0 {
+		t.Fatalf("Expected no trigger to run when the template can't be fetched")
+	}
+
+	q.resume(func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return newSuspendTestTemplate(name, false), nil
+	})
+	if runs != 1 {
+		t.Errorf("Expected queued trigger to run once the template is fetched, got %d runs", runs)
+	}
+}
+
+func TestSuspendQueueForgetsDeletedTemplate(t *testing.T) {
+	q := newSuspendQueue()
+	template := newSuspendTestTemplate("test-template", true)
+
+	runs := 0
+	q.queue(template, func(latest *v1alpha1.EventTriggeredJob) { runs++ })
+
+	q.resume(func(namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+		return nil, errors.NewNotFound(v1alpha1.Resource("eventtriggeredjobs"), name)
+	})
+	if runs != 0 || len(q.pending) != 0 {
+		t.Errorf("Expected the trigger of the deleted template to be dropped, got %d runs and %d pending", runs, len(q.pending))
+	}
+}
+
+func TestSuspendedCondition(t *testing.T) {
+	template := newSuspendTestTemplate("test-template", true)
+	template.Generation = 3
+
+	condition := suspendedCondition(template, true)
+	if condition.Type != v1alpha1.ConditionSuspended || condition.Status != metav1.ConditionTrue {
+		t.Errorf("Expected Suspended=True, got %s=%s", condition.Type, condition.Status)
+	}
+	if condition.ObservedGeneration != 3 {
+		t.Errorf("Expected observed generation 3, got %d", condition.ObservedGeneration)
+	}
+
+	if condition := suspendedCondition(template, false); condition.Status != metav1.ConditionFalse {
+		t.Errorf("Expected Suspended=False for a resumed template, got %s", condition.Status)
+	}
+}
+
+func TestReconcileSuspendedCondition(t *testing.T) {
+	suspended := newSuspendTestTemplate("suspended", true)
+	never := newSuspendTestTemplate("never-suspended", false)
+	kubananaClient := kubananafake.NewSimpleClientset(suspended, never)
+	ctx := context.Background()
+
+	reconcileSuspendedCondition(ctx, kubananaClient, suspended)
+	updated, err := fetchTemplate(ctx, kubananaClient, "default", "suspended")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ConditionSuspended) {
+		t.Fatalf("Expected a suspended template to get Suspended=True, got %v", updated.Status.Conditions)
+	}
+
+	// Resuming the template clears the condition without a trigger arriving
+	resumed := updated.DeepCopy()
+	resumed.Spec.Suspend = &[]bool{false}[0]
+	if _, err := kubananaClient.KubananaV1alpha1().EventTriggeredJobs("default").Update(ctx, resumed, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to resume template: %v", err)
+	}
+	reconcileSuspendedCondition(ctx, kubananaClient, resumed)
+	if updated, _ = fetchTemplate(ctx, kubananaClient, "default", "suspended"); !meta.IsStatusConditionFalse(updated.Status.Conditions, v1alpha1.ConditionSuspended) {
+		t.Errorf("Expected a resumed template to get Suspended=False, got %v", updated.Status.Conditions)
+	}
+
+	reconcileSuspendedCondition(ctx, kubananaClient, never)
+	if updated, _ = fetchTemplate(ctx, kubananaClient, "default", "never-suspended"); len(updated.Status.Conditions) != 0 {
+		t.Errorf("Expected no condition on a template that was never suspended, got %v", updated.Status.Conditions)
+	}
+}
+
+func TestSuspendedConditionHandlerSkipsStandby(t *testing.T) {
+	template := newSuspendTestTemplate("test-template", true)
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+
+	var standby atomic.Bool
+	standby.Store(true)
+	handler := suspendedConditionHandler(kubananaClient, &standby)
+
+	handler.OnAdd(template, false)
+	if updated, _ := fetchTemplate(context.Background(), kubananaClient, "default", "test-template"); len(updated.Status.Conditions) != 0 {
+		t.Fatalf("Expected a standby not to update the status, got %v", updated.Status.Conditions)
+	}
+
+	standby.Store(false)
+	handler.OnUpdate(template, template)
+	if updated, _ := fetchTemplate(context.Background(), kubananaClient, "default", "test-template"); !meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ConditionSuspended) {
+		t.Errorf("Expected the leader to set Suspended=True, got %v", updated.Status.Conditions)
+	}
+}
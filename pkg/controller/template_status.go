@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// Trigger types reported in metrics
+const (
+	eventTrigger  = "event"
+	statusTrigger = "status"
+)
+
+// fetchTemplate gets the current version of a template from the API server
+func fetchTemplate(ctx context.Context, kubananaClient versioned.Interface, namespace, name string) (*v1alpha1.EventTriggeredJob, error) {
+	return kubananaClient.KubananaV1alpha1().EventTriggeredJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// updateTemplateStatus applies mutate to the latest version of the template's status and writes it back
+func updateTemplateStatus(
+	ctx context.Context,
+	kubananaClient versioned.Interface,
+	template *v1alpha1.EventTriggeredJob,
+	mutate func(status *v1alpha1.EventTriggeredJobStatus)) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := fetchTemplate(ctx, kubananaClient, template.Namespace, template.Name)
+		if err != nil {
+			return err
+		}
+
+		mutate(&latest.Status)
+
+		_, err = kubananaClient.KubananaV1alpha1().EventTriggeredJobs(template.Namespace).
+			UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// recordCreatedJob counts a job created by the template and when it was triggered
+func recordCreatedJob(ctx context.Context, kubananaClient versioned.Interface, template *v1alpha1.EventTriggeredJob) {
+	now := metav1.Now()
+	err := updateTemplateStatus(ctx, kubananaClient, template, func(status *v1alpha1.EventTriggeredJobStatus) {
+		status.JobsCreated++
+		status.LastTriggeredTime = &now
+	})
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to update template status")
+	}
+}
+
+// droppedTriggerFlushDelay is how long the dropped triggers of a template are counted before
+// they're written to its status and reported in a single update
+const droppedTriggerFlushDelay = 2 * time.Second
+
+// recordDroppedTrigger counts a trigger that matched the template but didn't create a job. The
+// template's status and events are only updated once per flush, so dropping triggers during a
+// storm doesn't cost an API write each.
+func recordDroppedTrigger(
+	ctx context.Context,
+	dropped *droppedTriggers,
+	template *v1alpha1.EventTriggeredJob,
+	trigger, reason string) {
+
+	klog.FromContext(ctx).Info("Dropped trigger", "reason", reason)
+	metrics.TriggerSkipped(template.Namespace, template.Name, trigger, reason, 1)
+	dropped.add(template, trigger, reason)
+}
+
+// droppedTriggers counts the dropped triggers of each template until they're flushed to its status
+type droppedTriggers struct {
+	kubananaClient versioned.Interface
+	recorder       record.EventRecorder
+	delay          time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*pendingDroppedTriggers
+}
+
+// pendingDroppedTriggers are the dropped triggers of a template waiting to be written
+type pendingDroppedTriggers struct {
+	template   *v1alpha1.EventTriggeredJob
+	counts     map[droppedTriggerKey]int64
+	lastReason string
+}
+
+// droppedTriggerKey groups dropped triggers reported in the same event
+type droppedTriggerKey struct {
+	trigger, reason string
+}
+
+// newDroppedTriggers creates a droppedTriggers writing to the templates' status and events
+func newDroppedTriggers(kubananaClient versioned.Interface, recorder record.EventRecorder) *droppedTriggers {
+	return &droppedTriggers{
+		kubananaClient: kubananaClient,
+		recorder:       recorder,
+		delay:          droppedTriggerFlushDelay,
+		pending:        make(map[string]*pendingDroppedTriggers),
+	}
+}
+
+// add counts the dropped trigger for the template, scheduling a flush if none is pending
+func (d *droppedTriggers) add(template *v1alpha1.EventTriggeredJob, trigger, reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := template.Namespace + "/" + template.Name
+	pending, exists := d.pending[key]
+	if !exists {
+		pending = &pendingDroppedTriggers{template: template, counts: make(map[droppedTriggerKey]int64)}
+		d.pending[key] = pending
+		time.AfterFunc(d.delay, func() {
+			d.flush(key)
+		})
+	}
+
+	pending.counts[droppedTriggerKey{trigger: trigger, reason: reason}]++
+	pending.lastReason = reason
+}
+
+// flush writes the dropped triggers of the template with key to its status and events
+func (d *droppedTriggers) flush(key string) {
+	d.mu.Lock()
+	pending, exists := d.pending[key]
+	delete(d.pending, key)
+	d.mu.Unlock()
+
+	if !exists {
+		return
+	}
+
+	var total int64
+	for dropped, count := range pending.counts {
+		total += count
+		if count == 1 {
+			d.recorder.Eventf(pending.template, corev1.EventTypeNormal, reasonTriggerSkipped,
+				"Skipped %s trigger: %s", dropped.trigger, dropped.reason)
+			continue
+		}
+		d.recorder.Eventf(pending.template, corev1.EventTypeNormal, reasonTriggerSkipped,
+			"Skipped %d %s triggers: %s", count, dropped.trigger, dropped.reason)
+	}
+
+	err := updateTemplateStatus(context.Background(), d.kubananaClient, pending.template, func(status *v1alpha1.EventTriggeredJobStatus) {
+		status.TriggersDropped += total
+		status.LastDropReason = pending.lastReason
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to update template status", logKeyTemplate, key)
+	}
+}
+
+// flushAll writes the dropped triggers of all templates, e.g. before the controller shuts down
+func (d *droppedTriggers) flushAll() {
+	d.mu.Lock()
+	keys := make([]string, 0, len(d.pending))
+	for key := range d.pending {
+		keys = append(keys, key)
+	}
+	d.mu.Unlock()
+
+	for _, key := range keys {
+		d.flush(key)
+	}
+}
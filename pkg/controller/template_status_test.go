@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordDroppedTrigger(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Status:     v1alpha1.EventTriggeredJobStatus{TriggersDropped: 2},
+	}
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+	recorder := record.NewFakeRecorder(10)
+	dropped := newDroppedTriggers(kubananaClient, recorder)
+	dropped.delay = time.Hour
+
+	for i := 0; i < 3; i++ {
+		recordDroppedTrigger(context.Background(), dropped, template, eventTrigger, "RateLimited")
+	}
+	recordDroppedTrigger(context.Background(), dropped, template, eventTrigger, "ResourceCooldown")
+
+	// Nothing is written until the dropped triggers are flushed
+	if actions := kubananaClient.Actions(); len(actions) != 0 || len(recorder.Events) != 0 {
+		t.Fatalf("Expected no writes before the flush, got %d actions and %d events", len(actions), len(recorder.Events))
+	}
+	dropped.flushAll()
+
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if updated.Status.TriggersDropped != 6 || updated.Status.LastDropReason != "ResourceCooldown" {
+		t.Errorf("Expected six dropped triggers with reason ResourceCooldown, got %+v", updated.Status)
+	}
+	updates := 0
+	for _, action := range kubananaClient.Actions() {
+		if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+			updates++
+		}
+	}
+	if updates != 1 {
+		t.Errorf("Expected a single status update, got %d", updates)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("Expected a TriggerSkipped event per reason, got %d events", len(recorder.Events))
+	}
+}
+
+func TestRecordCreatedJob(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Status:     v1alpha1.EventTriggeredJobStatus{JobsCreated: 4},
+	}
+	kubananaClient := kubananafake.NewSimpleClientset(template)
+
+	recordCreatedJob(context.Background(), kubananaClient, template)
+
+	updated, err := fetchTemplate(context.Background(), kubananaClient, "default", "test-template")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if updated.Status.JobsCreated != 5 || updated.Status.LastTriggeredTime == nil {
+		t.Errorf("Expected a fifth created job with its trigger time, got %+v", updated.Status)
+	}
+}
+
+func TestUpdateTemplateStatusMissingTemplate(t *testing.T) {
+	template := &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+	}
+
+	err := updateTemplateStatus(context.Background(), kubananafake.NewSimpleClientset(), template,
+		func(status *v1alpha1.EventTriggeredJobStatus) {
+			status.JobsCreated++
+		})
+	if err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	kubananainformers "github.com/roshbhatia/kubanana/pkg/client/informers/externalversions/kubanana/v1alpha1"
+	kubananalisters "github.com/roshbhatia/kubanana/pkg/client/listers/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// newTemplateInformer creates informers caching the EventTriggeredJobs of the watched namespaces
+func newTemplateInformer(
+	kubananaClient versioned.Interface,
+	namespaces []string,
+	resyncPeriod time.Duration) (*namespacedInformer, kubananalisters.EventTriggeredJobLister) {
+
+	informer := newNamespacedInformer(namespaces, func(namespace string) cache.SharedIndexInformer {
+		return kubananainformers.NewEventTriggeredJobInformer(kubananaClient, namespace, resyncPeriod, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+	})
+	metrics.RegisterInformer(v1alpha1.SchemeGroupVersion.WithKind("EventTriggeredJob").String(), informer)
+
+	lister := &templateLister{listers: map[string]kubananalisters.EventTriggeredJobLister{}}
+	for namespace, namespaceInformer := range informer.informers {
+		lister.listers[namespace] = kubananalisters.NewEventTriggeredJobLister(namespaceInformer.GetIndexer())
+	}
+
+	return informer, lister
+}
+
+// templateLister lists the templates cached by the informers of all watched namespaces
+type templateLister struct {
+	listers map[string]kubananalisters.EventTriggeredJobLister
+}
+
+// List lists the templates of all watched namespaces
+func (l *templateLister) List(selector labels.Selector) ([]*v1alpha1.EventTriggeredJob, error) {
+	var templates []*v1alpha1.EventTriggeredJob
+	for _, lister := range l.listers {
+		namespaceTemplates, err := lister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, namespaceTemplates...)
+	}
+
+	return templates, nil
+}
+
+// EventTriggeredJobs lists the templates of namespace, which has none if it isn't watched
+func (l *templateLister) EventTriggeredJobs(namespace string) kubananalisters.EventTriggeredJobNamespaceLister {
+	if lister, ok := l.listers[namespace]; ok {
+		return lister.EventTriggeredJobs(namespace)
+	}
+	if lister, ok := l.listers[metav1.NamespaceAll]; ok {
+		return lister.EventTriggeredJobs(namespace)
+	}
+
+	empty := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	return kubananalisters.NewEventTriggeredJobLister(empty).EventTriggeredJobs(namespace)
+}
+
+// listTemplates returns copies of the cached templates, so callers are free to modify them
+func listTemplates(lister kubananalisters.EventTriggeredJobLister) ([]*v1alpha1.EventTriggeredJob, error) {
+	cached, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*v1alpha1.EventTriggeredJob, 0, len(cached))
+	for _, template := range cached {
+		templates = append(templates, template.DeepCopy())
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Spans of a trigger, from its receipt to the job it created
+const (
+	spanReceiveEvent        = "ReceiveEvent"
+	spanReceiveStatusChange = "ReceiveStatusChange"
+	spanEvaluateTemplate    = "EvaluateTemplate"
+	spanCreateJob           = "CreateJob"
+)
+
+const (
+	// traceParentAnnotation carries the W3C trace context of the span that created a job
+	traceParentAnnotation = "kubanana.roshanbhatia.com/traceparent"
+
+	// traceParentEnv passes the trace context to the job's containers, so their workloads can continue the trace
+	traceParentEnv = "TRACEPARENT"
+)
+
+// startSpan starts a span of the controller's tracer
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// triggerAttributes describe the trigger of a span
+func triggerAttributes(trigger, resource string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("kubanana.trigger", trigger),
+		attribute.String("kubanana.resource", resource),
+	}
+}
+
+// templateAttributes describe the template evaluated in a span
+func templateAttributes(template *v1alpha1.EventTriggeredJob) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("kubanana.template.namespace", template.Namespace),
+		attribute.String("kubanana.template.name", template.Name),
+	}
+}
+
+// endSpan marks span as failed if err is set and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// injectTraceParent records the trace context of ctx on the job and its pods and exposes it to every
+// container that doesn't set TRACEPARENT itself
+func injectTraceParent(ctx context.Context, job *batchv1.Job) {
+	traceParent := tracing.TraceParent(ctx)
+	if traceParent == "" {
+		return
+	}
+
+	for _, objMeta := range []*metav1.ObjectMeta{&job.ObjectMeta, &job.Spec.Template.ObjectMeta} {
+		objMeta.Annotations = copyStringMap(objMeta.Annotations)
+		objMeta.Annotations[traceParentAnnotation] = traceParent
+	}
+
+	for i, container := range job.Spec.Template.Spec.Containers {
+		if hasEnv(container, traceParentEnv) {
+			continue
+		}
+		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env,
+			corev1.EnvVar{Name: traceParentEnv, Value: traceParent})
+	}
+}
+
+// hasEnv checks if the container sets the env var name
+func hasEnv(container corev1.Container, name string) bool {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// recordSpans records the spans of the controller's tracer until the test ends
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func newTracedJob() *batchv1.Job {
+	job := &batchv1.Job{}
+	job.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "worker"},
+		{Name: "sidecar", Env: []corev1.EnvVar{{Name: traceParentEnv, Value: "custom"}}},
+	}
+	return job
+}
+
+func TestInjectTraceParent(t *testing.T) {
+	recordSpans(t)
+	ctx, span := startSpan(context.Background(), spanCreateJob)
+	defer span.End()
+
+	job := newTracedJob()
+	injectTraceParent(ctx, job)
+
+	traceParent := job.Annotations[traceParentAnnotation]
+	if !strings.Contains(traceParent, span.SpanContext().SpanID().String()) {
+		t.Fatalf("Expected the traceparent of the span on the job, got %q", traceParent)
+	}
+	if job.Spec.Template.Annotations[traceParentAnnotation] != traceParent {
+		t.Errorf("Expected the traceparent on the pod template, got %v", job.Spec.Template.Annotations)
+	}
+
+	containers := job.Spec.Template.Spec.Containers
+	if len(containers[0].Env) != 1 || containers[0].Env[0] != (corev1.EnvVar{Name: traceParentEnv, Value: traceParent}) {
+		t.Errorf("Expected TRACEPARENT on the container, got %v", containers[0].Env)
+	}
+	if len(containers[1].Env) != 1 || containers[1].Env[0].Value != "custom" {
+		t.Errorf("Expected the container's own TRACEPARENT to be kept, got %v", containers[1].Env)
+	}
+}
+
+func TestInjectTraceParentWithoutSpan(t *testing.T) {
+	job := newTracedJob()
+	injectTraceParent(context.Background(), job)
+
+	if len(job.Annotations) != 0 || len(job.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("Expected no trace context without a span, got %v", job)
+	}
+}
+
+func TestCreateJobSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	template := newRateLimitedTemplate(nil)
+	template.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Name: "worker", Image: "busybox"}}
+	c := NewEventControllerWithOptions(fake.NewSimpleClientset(), kubananafake.NewSimpleClientset(template), Options{})
+	event := &corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}}
+
+	ctx, span := startSpan(context.Background(), spanEvaluateTemplate, templateAttributes(template)...)
+	ctx = withTrigger(ctx, template, eventTrigger, triggerResource("Pod", "default", "web-1"))
+	job, err := c.createJobFromTemplate(ctx, template, event, "CREATE", triggerOrigin{}, nil)
+	span.End()
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	if traceIDFromContext(ctx) != span.SpanContext().TraceID().String() {
+		t.Errorf("Expected the logs to use the span's trace ID, got %q", traceIDFromContext(ctx))
+	}
+
+	var createSpan sdktrace.ReadOnlySpan
+	for _, ended := range recorder.Ended() {
+		if ended.Name() == spanCreateJob {
+			createSpan = ended
+		}
+	}
+	if createSpan == nil {
+		t.Fatalf("Expected a %s span", spanCreateJob)
+	}
+	if createSpan.Parent().SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("Expected the %s span to be a child of the template evaluation", spanCreateJob)
+	}
+	if traceParent := job.Annotations[traceParentAnnotation]; !strings.Contains(traceParent, createSpan.SpanContext().SpanID().String()) {
+		t.Errorf("Expected the job to continue the %s span, got traceparent %q", spanCreateJob, traceParent)
+	}
+}
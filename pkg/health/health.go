@@ -0,0 +1,51 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Check returns an error if the checked component isn't healthy
+type Check func() error
+
+// Handler serves the result of all checks. It responds 200 if every check passes and 500
+// listing the failed checks otherwise.
+func Handler(checks map[string]Check) http.Handler {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var failed []string
+		for _, name := range names {
+			if err := checks[name](); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		if len(failed) > 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "%s\n", strings.Join(failed, "\n"))
+			return
+		}
+
+		fmt.Fprint(w, "ok")
+	})
+}
+
+// SyncCheck turns a HasSynced func into a Check
+func SyncCheck(hasSynced func() bool) Check {
+	return func() error {
+		if !hasSynced() {
+			return fmt.Errorf("informer caches not synced")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	synced := false
+	handler := Handler(map[string]Check{
+		"ok":     func() error { return nil },
+		"synced": SyncCheck(func() bool { return synced }),
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 before the caches synced, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "synced: informer caches not synced") {
+		t.Errorf("Expected failed check in the body, got %q", rec.Body.String())
+	}
+
+	synced = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 once all checks pass, got %d", rec.Code)
+	}
+}
+
+func TestHandlerListsAllFailures(t *testing.T) {
+	handler := Handler(map[string]Check{
+		"event":  func() error { return fmt.Errorf("worker event-0 died") },
+		"status": func() error { return fmt.Errorf("worker status-1 died") },
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	expected := "event: worker event-0 died\nstatus: worker status-1 died\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Expected body %q, got %q", expected, rec.Body.String())
+	}
+}
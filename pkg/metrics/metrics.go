@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kubanana"
+
+// Registry holds all metrics exposed by the controller
+var Registry = prometheus.NewRegistry()
+
+var (
+	eventsReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_received_total",
+		Help:      "Number of events and status changes received, by trigger type.",
+	}, []string{"trigger"})
+
+	templatesEvaluated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "templates_evaluated_total",
+		Help:      "Number of times a template was evaluated against a trigger.",
+	}, []string{"namespace", "template", "trigger"})
+
+	templateMatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "template_matches_total",
+		Help:      "Number of triggers that matched a template.",
+	}, []string{"namespace", "template", "trigger"})
+
+	jobsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_created_total",
+		Help:      "Number of jobs created from a template.",
+	}, []string{"namespace", "template", "trigger"})
+
+	jobsDryRun = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_dry_run_total",
+		Help:      "Number of jobs a dry run would have created from a template.",
+	}, []string{"namespace", "template", "trigger"})
+
+	jobCreationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_creation_failures_total",
+		Help:      "Number of jobs that failed to be created from a template.",
+	}, []string{"namespace", "template", "trigger"})
+
+	triggersSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "triggers_skipped_total",
+		Help:      "Number of matched triggers that didn't create a job, by reason.",
+	}, []string{"namespace", "template", "trigger", "reason"})
+
+	informerObjects = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "informer", "objects"),
+		"Number of objects in an informer cache, by GroupVersionKind.",
+		[]string{"gvk"}, nil)
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		eventsReceived,
+		templatesEvaluated,
+		templateMatches,
+		jobsCreated,
+		jobsDryRun,
+		jobCreationFailures,
+		triggersSkipped,
+		informers,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// EventReceived counts an event or status change received by a controller
+func EventReceived(trigger string) {
+	eventsReceived.WithLabelValues(trigger).Inc()
+}
+
+// TemplateEvaluated counts a template being evaluated against a trigger
+func TemplateEvaluated(namespace, template, trigger string) {
+	templatesEvaluated.WithLabelValues(namespace, template, trigger).Inc()
+}
+
+// TemplateMatched counts a trigger that matched a template
+func TemplateMatched(namespace, template, trigger string) {
+	templateMatches.WithLabelValues(namespace, template, trigger).Inc()
+}
+
+// JobCreated counts a job created from a template
+func JobCreated(namespace, template, trigger string) {
+	jobsCreated.WithLabelValues(namespace, template, trigger).Inc()
+}
+
+// JobDryRun counts a job a dry run would have created from a template
+func JobDryRun(namespace, template, trigger string) {
+	jobsDryRun.WithLabelValues(namespace, template, trigger).Inc()
+}
+
+// JobCreationFailed counts a job that failed to be created from a template
+func JobCreationFailed(namespace, template, trigger string) {
+	jobCreationFailures.WithLabelValues(namespace, template, trigger).Inc()
+}
+
+// TriggerSkipped counts matched triggers that didn't create a job, by reason
+func TriggerSkipped(namespace, template, trigger, reason string, count int) {
+	triggersSkipped.WithLabelValues(namespace, template, trigger, reason).Add(float64(count))
+}
+
+// KeyLister lists the keys of cached objects, e.g. a cache.Store
+type KeyLister interface {
+	ListKeys() []string
+}
+
+// informerCollector reports the size of every registered informer cache at scrape time
+type informerCollector struct {
+	mu     sync.Mutex
+	stores map[string]KeyLister
+}
+
+var informers = &informerCollector{stores: make(map[string]KeyLister)}
+
+// RegisterInformer reports the number of objects in store under gvk
+func RegisterInformer(gvk string, store KeyLister) {
+	informers.mu.Lock()
+	defer informers.mu.Unlock()
+
+	informers.stores[gvk] = store
+}
+
+// Describe implements prometheus.Collector
+func (c *informerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- informerObjects
+}
+
+// Collect implements prometheus.Collector
+func (c *informerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for gvk, store := range c.stores {
+		ch <- prometheus.MustNewConstMetric(informerObjects, prometheus.GaugeValue, float64(len(store.ListKeys())), gvk)
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestTriggerCounters(t *testing.T) {
+	EventReceived("event")
+	TemplateEvaluated("default", "counter-template", "event")
+	TemplateMatched("default", "counter-template", "event")
+	JobCreated("default", "counter-template", "event")
+	JobDryRun("default", "counter-template", "status")
+	JobCreationFailed("default", "counter-template", "status")
+	TriggerSkipped("default", "counter-template", "event", "RateLimited", 1)
+	TriggerSkipped("default", "counter-template", "event", "Debounced", 3)
+
+	if v := testutil.ToFloat64(templateMatches.WithLabelValues("default", "counter-template", "event")); v != 1 {
+		t.Errorf("Expected 1 match, got %v", v)
+	}
+	if v := testutil.ToFloat64(jobsCreated.WithLabelValues("default", "counter-template", "event")); v != 1 {
+		t.Errorf("Expected 1 created job, got %v", v)
+	}
+	if v := testutil.ToFloat64(jobsDryRun.WithLabelValues("default", "counter-template", "status")); v != 1 {
+		t.Errorf("Expected 1 dry-run job, got %v", v)
+	}
+	if v := testutil.ToFloat64(jobCreationFailures.WithLabelValues("default", "counter-template", "status")); v != 1 {
+		t.Errorf("Expected 1 creation failure, got %v", v)
+	}
+	if v := testutil.ToFloat64(triggersSkipped.WithLabelValues("default", "counter-template", "event", "Debounced")); v != 3 {
+		t.Errorf("Expected 3 debounced triggers, got %v", v)
+	}
+}
+
+func TestInformerCollector(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	RegisterInformer("/v1, Kind=TestObject", store)
+
+	for _, name := range []string{"a", "b"} {
+		_ = store.Add(&metav1.ObjectMeta{Name: name, Namespace: "default"})
+	}
+
+	expected := `
+# HELP kubanana_informer_objects Number of objects in an informer cache, by GroupVersionKind.
+# TYPE kubanana_informer_objects gauge
+kubanana_informer_objects{gvk="/v1, Kind=TestObject"} 2
+`
+	if err := testutil.CollectAndCompare(informers, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWorkqueueMetrics(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(),
+		workqueue.RateLimitingQueueConfig{Name: "test-queue"})
+	defer queue.ShutDown()
+
+	queue.Add("default/a")
+	queue.Add("default/b")
+
+	if v := testutil.ToFloat64(workqueueDepth.WithLabelValues("test-queue")); v != 2 {
+		t.Errorf("Expected depth 2, got %v", v)
+	}
+	if v := testutil.ToFloat64(workqueueAdds.WithLabelValues("test-queue")); v != 2 {
+		t.Errorf("Expected 2 adds, got %v", v)
+	}
+}
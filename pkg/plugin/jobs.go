@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// jobs prints the jobs a template created with what triggered them
+func (p *Plugin) jobs(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+	name, err := templateName(args)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := templateJobs(ctx, clients.KubeClient, listNamespace(clients, common), clients.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		fmt.Fprintf(p.Out, "No jobs found for template %s/%s\n", clients.Namespace, name)
+		return nil
+	}
+
+	table := newTable(p.Out)
+	if common.allNamespaces {
+		fmt.Fprint(table, "NAMESPACE\t")
+	}
+	fmt.Fprintln(table, "NAME\tRESOURCE\tTRIGGER\tSTATUS\tAGE")
+
+	for i := range jobs {
+		job := &jobs[i]
+		if common.allNamespaces {
+			fmt.Fprintf(table, "%s\t", job.Namespace)
+		}
+		fmt.Fprintf(table, "%s\t%s/%s\t%s\t%s\t%s\n",
+			job.Name,
+			job.Labels[controller.ResourceKindLabel],
+			job.Labels[controller.ResourceNameLabel],
+			jobTrigger(job),
+			jobStatus(job),
+			age(job.CreationTimestamp))
+	}
+
+	return table.Flush()
+}
+
+// templateJobs lists the jobs in namespace created by the template name of templateNamespace, the most recent first
+func templateJobs(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespace, templateNamespace, name string) ([]batchv1.Job, error) {
+
+	selector := labels.Set{
+		controller.TemplateLabel:          name,
+		controller.TemplateNamespaceLabel: templateNamespace,
+	}.AsSelector().String()
+
+	jobs, err := kubeClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs of template %s/%s: %w", templateNamespace, name, err)
+	}
+
+	sortJobsNewestFirst(jobs.Items)
+	return jobs.Items, nil
+}
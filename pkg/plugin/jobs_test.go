@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestJobsListsTheJobsOfATemplate(t *testing.T) {
+	other := newPluginTestJob("other-job", "default", "other", time.Minute)
+	out, _, err := runPlugin(t,
+		[]runtime.Object{
+			newPluginTestJob("old-job", "default", "my-template", time.Hour),
+			newPluginTestJob("new-job", "default", "my-template", time.Minute),
+			other,
+		},
+		nil,
+		"jobs", "my-template")
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and the two jobs of the template, got:\n%s", out)
+	}
+	if fields := strings.Fields(lines[1]); fields[0] != "new-job" || fields[1] != "Pod/web-1" || fields[2] != "CREATE" {
+		t.Errorf("Expected the most recent job first with its trigger, got %q", lines[1])
+	}
+}
+
+func TestJobsWithoutJobs(t *testing.T) {
+	out, _, err := runPlugin(t, nil, nil, "jobs", "my-template")
+	if err != nil || !strings.Contains(out, "No jobs found") {
+		t.Errorf("Expected no jobs to be found, got %q, %v", out, err)
+	}
+
+	if _, _, err := runPlugin(t, nil, nil, "jobs"); err == nil {
+		t.Errorf("Expected jobs without a template name to fail")
+	}
+}
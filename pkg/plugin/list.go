@@ -0,0 +1,93 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// list prints the templates with their status and most recent job
+func (p *Plugin) list(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("list takes no arguments, got %d", len(args))
+	}
+
+	namespace := listNamespace(clients, common)
+	templates, err := clients.KubananaClient.KubananaV1alpha1().EventTriggeredJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+	if len(templates.Items) == 0 {
+		fmt.Fprintln(p.Out, "No templates found")
+		return nil
+	}
+
+	jobs, err := clients.KubeClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: controller.TemplateLabel})
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	sortJobsNewestFirst(jobs.Items)
+
+	// The jobs are sorted, so the first one seen for a template is its most recent
+	lastJobs := map[string]*batchv1.Job{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		key := job.Labels[controller.TemplateNamespaceLabel] + "/" + job.Labels[controller.TemplateLabel]
+		if _, ok := lastJobs[key]; !ok {
+			lastJobs[key] = job
+		}
+	}
+
+	table := newTable(p.Out)
+	if common.allNamespaces {
+		fmt.Fprint(table, "NAMESPACE\t")
+	}
+	fmt.Fprintln(table, "NAME\tTRIGGER\tSUSPENDED\tJOBS\tDROPPED\tLAST JOB\tLAST TRIGGERED\tAGE")
+
+	for i := range templates.Items {
+		template := &templates.Items[i]
+
+		lastJob := "<none>"
+		if job, ok := lastJobs[template.Namespace+"/"+template.Name]; ok {
+			lastJob = fmt.Sprintf("%s (%s)", job.Name, jobStatus(job))
+		}
+		lastTriggered := "<none>"
+		if template.Status.LastTriggeredTime != nil {
+			lastTriggered = age(*template.Status.LastTriggeredTime)
+		}
+
+		if common.allNamespaces {
+			fmt.Fprintf(table, "%s\t", template.Namespace)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			template.Name,
+			templateTrigger(template),
+			strconv.FormatBool(template.Spec.Suspend != nil && *template.Spec.Suspend),
+			template.Status.JobsCreated,
+			template.Status.TriggersDropped,
+			lastJob,
+			lastTriggered,
+			age(template.CreationTimestamp))
+	}
+
+	return table.Flush()
+}
+
+// templateTrigger describes the selectors of a template, e.g. event/Pod
+func templateTrigger(template *v1alpha1.EventTriggeredJob) string {
+	var triggers []string
+	if selector := template.Spec.EventSelector; selector != nil {
+		triggers = append(triggers, "event/"+selector.ResourceKind)
+	}
+	if selector := template.Spec.StatusSelector; selector != nil {
+		triggers = append(triggers, "status/"+selector.ResourceKind)
+	}
+
+	return strings.Join(triggers, ",")
+}
@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestListShowsTemplatesWithTheirLastJob(t *testing.T) {
+	suspended := newPluginTestTemplate("suspended", "default")
+	suspended.Spec.Suspend = &[]bool{true}[0]
+	suspended.Status.JobsCreated = 2
+
+	out, _, err := runPlugin(t,
+		[]runtime.Object{
+			newPluginTestJob("suspended-old", "default", "suspended", time.Hour),
+			newPluginTestJob("suspended-new", "default", "suspended", time.Minute),
+		},
+		[]runtime.Object{
+			suspended,
+			newPluginTestTemplate("idle", "default"),
+			newPluginTestTemplate("elsewhere", "team-a"),
+		},
+		"list")
+	if err != nil {
+		t.Fatalf("Failed to list templates: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and the two templates of namespace default, got:\n%s", out)
+	}
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "suspended":
+			if fields[1] != "event/Pod" || fields[2] != "true" || fields[3] != "2" || fields[5] != "suspended-new" {
+				t.Errorf("Expected the suspended template with its most recent job, got %q", line)
+			}
+		case "idle":
+			if fields[2] != "false" || fields[5] != "<none>" {
+				t.Errorf("Expected the idle template without jobs, got %q", line)
+			}
+		default:
+			t.Errorf("Unexpected template %q", line)
+		}
+	}
+}
+
+func TestListAllNamespaces(t *testing.T) {
+	out, _, err := runPlugin(t, nil,
+		[]runtime.Object{newPluginTestTemplate("idle", "default"), newPluginTestTemplate("elsewhere", "team-a")},
+		"list", "-A")
+	if err != nil {
+		t.Fatalf("Failed to list templates: %v", err)
+	}
+
+	if !strings.HasPrefix(out, "NAMESPACE") || !strings.Contains(out, "team-a") {
+		t.Errorf("Expected the templates of all namespaces, got:\n%s", out)
+	}
+}
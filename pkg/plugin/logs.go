@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// jobNameLabel is set by the Job controller on the pods of a job
+const jobNameLabel = "job-name"
+
+// logs prints the logs of the newest pod of the template's most recent job
+func (p *Plugin) logs(
+	ctx context.Context,
+	clients *Clients,
+	common commonFlags,
+	args []string,
+	follow bool,
+	container string) error {
+
+	name, err := templateName(args)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := templateJobs(ctx, clients.KubeClient, listNamespace(clients, common), clients.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return fmt.Errorf("template %s/%s has no jobs", clients.Namespace, name)
+	}
+	job := jobs[0]
+
+	selector := labels.Set{jobNameLabel: job.Name}.AsSelector().String()
+	pods, err := clients.KubeClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list pods of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("job %s/%s has no pods", job.Namespace, job.Name)
+	}
+
+	// Retried jobs have several pods, the newest one is the interesting one
+	sort.SliceStable(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	pod := pods.Items[0]
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	stream, err := clients.KubeClient.CoreV1().Pods(pod.Namespace).
+		GetLogs(pod.Name, &corev1.PodLogOptions{Container: container, Follow: follow}).
+		Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(p.Out, stream)
+	return err
+}
@@ -0,0 +1,48 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestLogsPrintsTheMostRecentJob(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "new-job-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{jobNameLabel: "new-job"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "hello"}}},
+	}
+
+	out, _, err := runPlugin(t,
+		[]runtime.Object{
+			newPluginTestJob("old-job", "default", "my-template", time.Hour),
+			newPluginTestJob("new-job", "default", "my-template", time.Minute),
+			pod,
+		},
+		nil,
+		"logs", "my-template")
+	if err != nil {
+		t.Fatalf("Failed to get logs: %v", err)
+	}
+
+	// The fake clientset answers every log request with "fake logs"
+	if out != "fake logs" {
+		t.Errorf("Expected the logs of the job's pod, got %q", out)
+	}
+}
+
+func TestLogsWithoutPods(t *testing.T) {
+	_, _, err := runPlugin(t,
+		[]runtime.Object{newPluginTestJob("new-job", "default", "my-template", time.Minute)},
+		nil,
+		"logs", "my-template")
+	if err == nil {
+		t.Errorf("Expected logs of a job without pods to fail")
+	}
+}
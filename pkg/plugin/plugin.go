@@ -0,0 +1,287 @@
+package plugin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/client/clientset/versioned"
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Usage describes the commands of the plugin
+const Usage = `Manage Kubanana EventTriggeredJobs.
+
+Usage:
+  kubectl kubanana list                                  List templates with their status and most recent job
+  kubectl kubanana jobs <template>                       List the jobs a template created and what triggered them
+  kubectl kubanana trigger <template> --resource <kind>/<namespace>/<name>
+                                                         Run a template as if the resource had triggered it
+  kubectl kubanana suspend <template>                    Stop a template from creating jobs
+  kubectl kubanana resume <template>                     Let a suspended template create jobs again
+  kubectl kubanana logs <template>                       Print the logs of the template's most recent job
+  kubectl kubanana test -f <template.yaml> --event <event.yaml>
+  kubectl kubanana test -f <template.yaml> --object <object.yaml> [--old <old.yaml>]
+                                                         Match a template against a sample event or object
+                                                         without a cluster and print the job it would create
+
+Flags of all commands:
+  -n, --namespace       Namespace of the templates, the current context's namespace by default
+  -A, --all-namespaces  List templates and jobs of all namespaces
+      --kubeconfig      Path to a kubeconfig
+      --context         Name of the kubeconfig context to use
+`
+
+// Clients are the API clients of a command, along with the namespace it works in
+type Clients struct {
+	KubeClient     kubernetes.Interface
+	KubananaClient versioned.Interface
+	Namespace      string
+}
+
+// ConnectFunc builds the clients for a kubeconfig and context, an empty namespace means the context's namespace
+type ConnectFunc func(kubeconfig, context, namespace string) (*Clients, error)
+
+// Plugin runs the kubectl kubanana commands
+type Plugin struct {
+	Out     io.Writer
+	Connect ConnectFunc
+}
+
+// commonFlags are accepted by every command
+type commonFlags struct {
+	kubeconfig    string
+	context       string
+	namespace     string
+	allNamespaces bool
+}
+
+// command runs a subcommand with its parsed positional arguments
+type command struct {
+	flags func(fs *flag.FlagSet)
+	run   func(ctx context.Context, clients *Clients, common commonFlags, args []string) error
+
+	// offline commands don't connect to a cluster and get no clients
+	offline bool
+}
+
+// Connect builds clients from the kubeconfig, the way kubectl does
+func Connect(kubeconfig, context, namespace string) (*Clients, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: context})
+
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace of the current context: %w", err)
+		}
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes clientset: %w", err)
+	}
+
+	kubananaClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubanana clientset: %w", err)
+	}
+
+	return &Clients{KubeClient: kubeClient, KubananaClient: kubananaClient, Namespace: namespace}, nil
+}
+
+// Run runs the command named by the first argument
+func (p *Plugin) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(p.Out, Usage)
+		return nil
+	}
+
+	commands := map[string]command{
+		"list":    {run: p.list},
+		"jobs":    {run: p.jobs},
+		"suspend": {run: p.suspend},
+		"resume":  {run: p.resume},
+	}
+	var trigger triggerFlags
+	commands["trigger"] = command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&trigger.resource, "resource", "", "Resource to trigger the template for, as <kind>/<namespace>/<name> or <kind>/<name>.")
+			fs.StringVar(&trigger.eventType, "event-type", "", "Event type to trigger event-based templates with, their first event type by default.")
+			fs.IntVar(&trigger.defaultJobTTLSeconds, "default-job-ttl-seconds", 86400, "ttlSecondsAfterFinished of the job if the template doesn't set one, like the controller's flag. A negative value disables the default.")
+			fs.IntVar(&trigger.defaultJobBackoffLimit, "default-job-backoff-limit", 6, "backoffLimit of the job if the template doesn't set one, like the controller's flag. A negative value leaves it to the Job API.")
+		},
+		run: func(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+			return p.trigger(ctx, clients, common, args, trigger)
+		},
+	}
+	var follow bool
+	var container string
+	commands["logs"] = command{
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&follow, "follow", false, "Keep streaming the logs.")
+			fs.BoolVar(&follow, "f", false, "Shorthand for --follow.")
+			fs.StringVar(&container, "container", "", "Container to print the logs of, the first one by default.")
+			fs.StringVar(&container, "c", "", "Shorthand for --container.")
+		},
+		run: func(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+			return p.logs(ctx, clients, common, args, follow, container)
+		},
+	}
+	var test testFlags
+	commands["test"] = command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&test.template, "filename", "", "File with the EventTriggeredJob to test.")
+			fs.StringVar(&test.template, "f", "", "Shorthand for --filename.")
+			fs.StringVar(&test.event, "event", "", "File with a sample Event to match event-based templates against.")
+			fs.StringVar(&test.object, "object", "", "File with a sample object to match status-based templates against.")
+			fs.StringVar(&test.old, "old", "", "File with the previous version of --object, to check that its status changed.")
+		},
+		run: func(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+			if len(args) > 0 {
+				return fmt.Errorf("test takes no arguments, got %d", len(args))
+			}
+			return p.testTemplate(common, test)
+		},
+		offline: true,
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run 'kubectl kubanana help' for usage", args[0])
+	}
+
+	fs := flag.NewFlagSet("kubectl kubanana "+args[0], flag.ContinueOnError)
+	fs.SetOutput(p.Out)
+	var common commonFlags
+	fs.StringVar(&common.kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	fs.StringVar(&common.context, "context", "", "Name of the kubeconfig context to use.")
+	fs.StringVar(&common.namespace, "namespace", "", "Namespace of the templates.")
+	fs.StringVar(&common.namespace, "n", "", "Shorthand for --namespace.")
+	fs.BoolVar(&common.allNamespaces, "all-namespaces", false, "List templates and jobs of all namespaces.")
+	fs.BoolVar(&common.allNamespaces, "A", false, "Shorthand for --all-namespaces.")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+
+	positional, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	if cmd.offline {
+		return cmd.run(ctx, nil, common, positional)
+	}
+
+	clients, err := p.Connect(common.kubeconfig, common.context, common.namespace)
+	if err != nil {
+		return err
+	}
+
+	return cmd.run(ctx, clients, common, positional)
+}
+
+// parseInterspersed parses flags that come before, between and after the positional arguments, which
+// flag.FlagSet stops at
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// templateName returns the single template name of a command's arguments
+func templateName(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected exactly one template name, got %d arguments", len(args))
+	}
+
+	return args[0], nil
+}
+
+// listNamespace returns the namespace to list objects in, metav1.NamespaceAll with --all-namespaces
+func listNamespace(clients *Clients, common commonFlags) string {
+	if common.allNamespaces {
+		return metav1.NamespaceAll
+	}
+
+	return clients.Namespace
+}
+
+// sortJobsNewestFirst sorts jobs by creation time, the most recent first
+func sortJobsNewestFirst(jobs []batchv1.Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+}
+
+// jobStatus describes the state of a job like kubectl does
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return "Suspended"
+	}
+
+	return "Running"
+}
+
+// jobTrigger describes what triggered a job from its labels
+func jobTrigger(job *batchv1.Job) string {
+	if eventType := job.Labels[controller.EventTypeLabel]; eventType != "" {
+		return eventType
+	}
+	if triggerType := job.Labels[controller.TriggerTypeLabel]; triggerType != "" {
+		return strings.ToUpper(triggerType)
+	}
+
+	return "<unknown>"
+}
+
+// age formats the time since t like kubectl does
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<none>"
+	}
+
+	return duration.HumanDuration(time.Since(t.Time))
+}
+
+// newTable creates a writer printing tab-separated columns aligned
+func newTable(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+}
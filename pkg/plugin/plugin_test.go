@@ -0,0 +1,117 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	kubananafake "github.com/roshbhatia/kubanana/pkg/client/clientset/versioned/fake"
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newPluginTestTemplate creates an event-based template in namespace
+func newPluginTestTemplate(name, namespace string) *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			EventSelector: &v1alpha1.EventSelector{ResourceKind: "Pod", EventTypes: []string{"CREATE"}},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "hello", Image: "busybox"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newPluginTestJob creates a job of the template name in namespace, created age ago
+func newPluginTestJob(name, namespace, template string, age time.Duration) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			Labels: map[string]string{
+				controller.TemplateLabel:          template,
+				controller.TemplateNamespaceLabel: namespace,
+				controller.ResourceKindLabel:      "Pod",
+				controller.ResourceNameLabel:      "web-1",
+				controller.EventTypeLabel:         "CREATE",
+			},
+		},
+	}
+}
+
+// runPlugin runs the plugin with args against fake clients in namespace default and returns its output
+func runPlugin(t *testing.T, kubeObjects, kubananaObjects []runtime.Object, args ...string) (string, *Clients, error) {
+	t.Helper()
+
+	clients := &Clients{
+		KubeClient:     fake.NewSimpleClientset(kubeObjects...),
+		KubananaClient: kubananafake.NewSimpleClientset(kubananaObjects...),
+	}
+	out := &bytes.Buffer{}
+	p := &Plugin{
+		Out: out,
+		Connect: func(kubeconfig, context, namespace string) (*Clients, error) {
+			clients.Namespace = namespace
+			if clients.Namespace == "" {
+				clients.Namespace = "default"
+			}
+			return clients, nil
+		},
+	}
+
+	err := p.Run(context.Background(), args)
+	return out.String(), clients, err
+}
+
+func TestRunPrintsUsage(t *testing.T) {
+	out, _, err := runPlugin(t, nil, nil)
+	if err != nil || !strings.Contains(out, "kubectl kubanana list") {
+		t.Errorf("Expected the usage without a command, got %q, %v", out, err)
+	}
+
+	if _, _, err := runPlugin(t, nil, nil, "explode"); err == nil {
+		t.Errorf("Expected an unknown command to fail")
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	namespace := fs.String("n", "", "")
+	follow := fs.Bool("f", false, "")
+
+	args, err := parseInterspersed(fs, []string{"-f", "my-template", "-n", "team-a"})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if len(args) != 1 || args[0] != "my-template" || *namespace != "team-a" || !*follow {
+		t.Errorf("Expected flags around the template name to be parsed, got args %v, -n %q, -f %v", args, *namespace, *follow)
+	}
+}
+
+func TestJobStatus(t *testing.T) {
+	job := &batchv1.Job{}
+	if status := jobStatus(job); status != "Running" {
+		t.Errorf("Expected an unfinished job to be Running, got %s", status)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	if status := jobStatus(job); status != "Failed" {
+		t.Errorf("Expected a failed job to be Failed, got %s", status)
+	}
+}
@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// suspend stops a template from creating jobs
+func (p *Plugin) suspend(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+	return p.setSuspend(ctx, clients, args, true)
+}
+
+// resume lets a suspended template create jobs again
+func (p *Plugin) resume(ctx context.Context, clients *Clients, common commonFlags, args []string) error {
+	return p.setSuspend(ctx, clients, args, false)
+}
+
+// setSuspend patches spec.suspend of the template named by args
+func (p *Plugin) setSuspend(ctx context.Context, clients *Clients, args []string, suspend bool) error {
+	name, err := templateName(args)
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	_, err = clients.KubananaClient.KubananaV1alpha1().EventTriggeredJobs(clients.Namespace).
+		Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update template %s/%s: %w", clients.Namespace, name, err)
+	}
+
+	action := "resumed"
+	if suspend {
+		action = "suspended"
+	}
+	fmt.Fprintf(p.Out, "eventtriggeredjob.kubanana.roshanbhatia.com/%s %s\n", name, action)
+	return nil
+}
@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSuspendAndResume(t *testing.T) {
+	template := newPluginTestTemplate("my-template", "team-a")
+
+	for _, tt := range []struct {
+		command string
+		suspend bool
+	}{
+		{command: "suspend", suspend: true},
+		{command: "resume", suspend: false},
+	} {
+		_, clients, err := runPlugin(t, nil, []runtime.Object{template}, tt.command, "my-template", "-n", "team-a")
+		if err != nil {
+			t.Fatalf("Failed to %s template: %v", tt.command, err)
+		}
+
+		updated, err := clients.KubananaClient.KubananaV1alpha1().EventTriggeredJobs("team-a").
+			Get(context.Background(), "my-template", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get template: %v", err)
+		}
+		if updated.Spec.Suspend == nil || *updated.Spec.Suspend != tt.suspend {
+			t.Errorf("Expected %s to set suspend to %v, got %v", tt.command, tt.suspend, updated.Spec.Suspend)
+		}
+	}
+}
+
+func TestSuspendMissingTemplate(t *testing.T) {
+	if _, _, err := runPlugin(t, nil, nil, "suspend", "missing"); err == nil {
+		t.Errorf("Expected suspending a missing template to fail")
+	}
+}
@@ -0,0 +1,207 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1beta1"
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	"github.com/roshbhatia/kubanana/pkg/webhook"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// errNoMatch is returned by the test command if the template doesn't match, so scripts can check the exit code
+var errNoMatch = errors.New("template doesn't match")
+
+// testFlags are the files the test command reads
+type testFlags struct {
+	template string
+	event    string
+	object   string
+	old      string
+}
+
+// testTemplate matches a template against a sample event or object without a cluster and prints the
+// reason for each selector field and the job it would create
+func (p *Plugin) testTemplate(common commonFlags, flags testFlags) error {
+	if flags.template == "" {
+		return fmt.Errorf("-f is required")
+	}
+	if (flags.event == "") == (flags.object == "") {
+		return fmt.Errorf("exactly one of --event and --object is required")
+	}
+	if flags.old != "" && flags.object == "" {
+		return fmt.Errorf("--old requires --object")
+	}
+
+	template, err := readTemplate(flags.template)
+	if err != nil {
+		return err
+	}
+	if template.Namespace == "" {
+		template.Namespace = common.namespace
+	}
+	if template.Namespace == "" {
+		template.Namespace = metav1.NamespaceDefault
+	}
+
+	// Templates are defaulted and validated on admission before the controllers see them
+	webhook.SetTemplateDefaults(template, webhook.Defaults{})
+	if errs := validation.ValidateTemplate(template); len(errs) > 0 {
+		return fmt.Errorf("template %s is invalid: %w", template.Name, errs.ToAggregate())
+	}
+
+	var result controller.MatchResult
+	var resource string
+	var render func() (*batchv1.Job, error)
+	if flags.event != "" {
+		event := &corev1.Event{}
+		if err := readObject(flags.event, event); err != nil {
+			return err
+		}
+
+		ref := event.InvolvedObject
+		resource = fmt.Sprintf("%s %s", ref.Kind, objectName(ref.Namespace, ref.Name))
+		result = controller.MatchEvent(template, event)
+		render = func() (*batchv1.Job, error) {
+			return controller.RenderEventJob(template, event)
+		}
+	} else {
+		obj := &unstructured.Unstructured{}
+		if err := readObject(flags.object, &obj.Object); err != nil {
+			return err
+		}
+		var old *unstructured.Unstructured
+		if flags.old != "" {
+			old = &unstructured.Unstructured{}
+			if err := readObject(flags.old, &old.Object); err != nil {
+				return err
+			}
+		}
+
+		conditions := controller.StatusConditions(obj.Object)
+		resource = fmt.Sprintf("%s %s", obj.GetKind(), objectName(obj.GetNamespace(), obj.GetName()))
+		result = matchStatusChange(template, obj, old, conditions)
+		render = func() (*batchv1.Job, error) {
+			return controller.RenderStatusJob(template, obj.GetKind(), obj.GetNamespace(), obj.GetName(), conditions)
+		}
+	}
+
+	matches := "matches"
+	if !result.Matched() {
+		matches = "doesn't match"
+	}
+	fmt.Fprintf(p.Out, "Template %s %s %s\n", objectName(template.Namespace, template.Name), matches, resource)
+
+	table := newTable(p.Out)
+	for _, field := range result.Fields {
+		outcome := "MATCH"
+		if !field.Matched {
+			outcome = "NO MATCH"
+		}
+		fmt.Fprintf(table, "  %s\t%s\t%s\n", outcome, field.Field, field.Reason)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if !result.Matched() {
+		return errNoMatch
+	}
+
+	job, err := render()
+	if err != nil {
+		return fmt.Errorf("failed to render job: %w", err)
+	}
+	job.APIVersion = batchv1.SchemeGroupVersion.String()
+	job.Kind = "Job"
+
+	out, err := yaml.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	fmt.Fprintf(p.Out, "---\n%s", out)
+
+	return nil
+}
+
+// matchStatusChange matches the status selector of template against obj, after checking that the status
+// controller would evaluate templates for obj at all: it needs status conditions that changed since old
+func matchStatusChange(
+	template *v1alpha1.EventTriggeredJob,
+	obj, old *unstructured.Unstructured,
+	conditions map[string]string) controller.MatchResult {
+
+	var status controller.FieldMatch
+	switch {
+	case len(conditions) == 0:
+		status = controller.FieldMatch{Field: "status.conditions", Reason: "object has no status conditions"}
+	case old != nil && !controller.StatusChanged(controller.StatusConditions(old.Object), conditions):
+		status = controller.FieldMatch{Field: "status.conditions", Reason: "conditions didn't change since the old object"}
+	case old != nil:
+		status = controller.FieldMatch{Field: "status.conditions", Matched: true, Reason: "conditions changed since the old object"}
+	default:
+		status = controller.FieldMatch{Field: "status.conditions", Matched: true, Reason: "object reports status conditions"}
+	}
+
+	result := controller.MatchStatus(template, obj.GetKind(), obj.GetNamespace(), obj.GetName(), conditions)
+	result.Fields = append([]controller.FieldMatch{status}, result.Fields...)
+	return result
+}
+
+// readTemplate reads a v1alpha1 or v1beta1 EventTriggeredJob and returns it as v1alpha1
+func readTemplate(path string) (*v1alpha1.EventTriggeredJob, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := readObject(path, typeMeta); err != nil {
+		return nil, err
+	}
+
+	if typeMeta.APIVersion != v1beta1.SchemeGroupVersion.String() {
+		template := &v1alpha1.EventTriggeredJob{}
+		if err := readObject(path, template); err != nil {
+			return nil, err
+		}
+		return template, nil
+	}
+
+	beta := &v1beta1.EventTriggeredJob{}
+	if err := readObject(path, beta); err != nil {
+		return nil, err
+	}
+	template := &v1alpha1.EventTriggeredJob{}
+	if err := beta.ConvertTo(template); err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %w", path, v1alpha1.SchemeGroupVersion, err)
+	}
+
+	return template, nil
+}
+
+// readObject decodes the YAML or JSON file at path into obj
+func readObject(path string, obj interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// objectName formats namespace/name, or only the name of cluster-scoped objects
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
@@ -0,0 +1,188 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testEventTemplate = `apiVersion: kubanana.roshanbhatia.com/v1alpha1
+kind: EventTriggeredJob
+metadata:
+  name: web-cleanup
+  namespace: prod-east
+spec:
+  eventSelector:
+    resourceKind: Pod
+    namePattern: "web-*"
+    eventTypes: ["create"]
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: busybox
+            command: ["echo", "$RESOURCE_NAME"]
+`
+
+const testStatusTemplate = `apiVersion: kubanana.roshanbhatia.com/v1beta1
+kind: EventTriggeredJob
+metadata:
+  name: web-ready
+spec:
+  target:
+    kind: Pod
+  triggers:
+  - status:
+      conditions:
+      - type: Ready
+        status: "True"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: notify
+            image: busybox
+`
+
+const testEvent = `apiVersion: v1
+kind: Event
+metadata:
+  name: web-1.17a
+  namespace: prod-east
+reason: Created
+involvedObject:
+  kind: Pod
+  name: web-1
+  namespace: prod-east
+`
+
+// testPod returns a pod manifest in namespace default that is ready or not
+func testPod(ready string) string {
+	return `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  conditions:
+  - type: Ready
+    status: "` + ready + `"
+`
+}
+
+// writeTestFiles writes files named by their keys to a temporary directory and returns their paths
+func writeTestFiles(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+
+	dir := t.TempDir()
+	paths := map[string]string{}
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	return paths
+}
+
+// fieldOutcome returns MATCH or NO MATCH of the field in the output of the test command
+func fieldOutcome(out, field string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if i := strings.Index(line, " "+field+" "); i >= 0 {
+			return strings.TrimSpace(line[:i])
+		}
+	}
+
+	return ""
+}
+
+func TestTestTemplateMatchesEvent(t *testing.T) {
+	paths := writeTestFiles(t, map[string]string{"template.yaml": testEventTemplate, "event.yaml": testEvent})
+
+	out, _, err := runPlugin(t, nil, nil, "test", "-f", paths["template.yaml"], "--event", paths["event.yaml"])
+	if err != nil {
+		t.Fatalf("Expected the event to match: %v\n%s", err, out)
+	}
+	if outcome := fieldOutcome(out, "spec.eventSelector.namePattern"); outcome != "MATCH" {
+		t.Errorf("Expected the name pattern to match, got %q:\n%s", outcome, out)
+	}
+
+	for _, expected := range []string{
+		"Template prod-east/web-cleanup matches Pod prod-east/web-1",
+		"generateName: web-cleanup-pod-create-",
+		"- web-1",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected the output to contain %q, got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestTestTemplateReportsEachField(t *testing.T) {
+	template := strings.Replace(testEventTemplate, `"web-*"`, `"api-*"`, 1)
+	paths := writeTestFiles(t, map[string]string{"template.yaml": template, "event.yaml": testEvent})
+
+	out, _, err := runPlugin(t, nil, nil, "test", "-f", paths["template.yaml"], "--event", paths["event.yaml"])
+	if !errors.Is(err, errNoMatch) {
+		t.Fatalf("Expected the event not to match, got %v", err)
+	}
+
+	if outcome := fieldOutcome(out, "spec.eventSelector.namePattern"); outcome != "NO MATCH" {
+		t.Errorf("Expected the name pattern not to match, got %q:\n%s", outcome, out)
+	}
+	if outcome := fieldOutcome(out, "spec.eventSelector.namespacePattern"); outcome != "MATCH" {
+		t.Errorf("Expected the fields after the name pattern to be reported too, got %q:\n%s", outcome, out)
+	}
+	if strings.Contains(out, "kind: Job") {
+		t.Errorf("Expected no job without a match, got:\n%s", out)
+	}
+}
+
+func TestTestTemplateMatchesStatusChange(t *testing.T) {
+	paths := writeTestFiles(t, map[string]string{
+		"template.yaml": testStatusTemplate,
+		"ready.yaml":    testPod("True"),
+		"unready.yaml":  testPod("False"),
+	})
+
+	out, _, err := runPlugin(t, nil, nil,
+		"test", "-f", paths["template.yaml"], "--object", paths["ready.yaml"], "--old", paths["unready.yaml"])
+	if err != nil {
+		t.Fatalf("Expected the pod becoming ready to match: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "STATUS_Ready") {
+		t.Errorf("Expected the job to get the conditions, got:\n%s", out)
+	}
+
+	out, _, err = runPlugin(t, nil, nil,
+		"test", "-f", paths["template.yaml"], "--object", paths["ready.yaml"], "--old", paths["ready.yaml"])
+	if !errors.Is(err, errNoMatch) || !strings.Contains(out, "conditions didn't change") {
+		t.Errorf("Expected an unchanged status not to match, got %v:\n%s", err, out)
+	}
+}
+
+func TestTestTemplateRejectsInvalidInput(t *testing.T) {
+	invalid := strings.Replace(testEventTemplate, `["create"]`, `["RESTART"]`, 1)
+	paths := writeTestFiles(t, map[string]string{
+		"template.yaml": testEventTemplate,
+		"invalid.yaml":  invalid,
+		"event.yaml":    testEvent,
+	})
+
+	for name, args := range map[string][]string{
+		"no template":     {"test", "--event", paths["event.yaml"]},
+		"no sample":       {"test", "-f", paths["template.yaml"]},
+		"old without obj": {"test", "-f", paths["template.yaml"], "--event", paths["event.yaml"], "--old", paths["event.yaml"]},
+		"invalid":         {"test", "-f", paths["invalid.yaml"], "--event", paths["event.yaml"]},
+	} {
+		if _, _, err := runPlugin(t, nil, nil, args...); err == nil || errors.Is(err, errNoMatch) {
+			t.Errorf("Expected %s to be rejected, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// triggerFlags are the flags of the trigger command
+type triggerFlags struct {
+	resource  string
+	eventType string
+
+	// The job defaults match the controller's flags of the same name, so the job looks like one the
+	// controller created
+	defaultJobTTLSeconds   int
+	defaultJobBackoffLimit int
+}
+
+// trigger creates the job a template runs for a resource, as if the resource had triggered it
+func (p *Plugin) trigger(
+	ctx context.Context,
+	clients *Clients,
+	common commonFlags,
+	args []string,
+	flags triggerFlags) error {
+
+	name, err := templateName(args)
+	if err != nil {
+		return err
+	}
+
+	template, err := clients.KubananaClient.KubananaV1alpha1().EventTriggeredJobs(clients.Namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get template %s/%s: %w", clients.Namespace, name, err)
+	}
+
+	kind, namespace, resourceName, err := parseResource(flags.resource, template.Namespace)
+	if err != nil {
+		return err
+	}
+
+	options := controller.Options{}
+	if flags.defaultJobTTLSeconds >= 0 {
+		ttl := int32(flags.defaultJobTTLSeconds)
+		options.DefaultJobTTLSeconds = &ttl
+	}
+	if flags.defaultJobBackoffLimit >= 0 {
+		backoffLimit := int32(flags.defaultJobBackoffLimit)
+		options.DefaultJobBackoffLimit = &backoffLimit
+	}
+
+	job, err := controller.NewManualJob(template, kind, namespace, resourceName, strings.ToUpper(flags.eventType), options)
+	if err != nil {
+		return fmt.Errorf("failed to render job of template %s/%s: %w", template.Namespace, template.Name, err)
+	}
+
+	created, err := clients.KubeClient.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create job of template %s/%s: %w", template.Namespace, template.Name, err)
+	}
+
+	fmt.Fprintf(p.Out, "job.batch/%s created in namespace %s\n", created.Name, created.Namespace)
+	return nil
+}
+
+// parseResource parses <kind>/<namespace>/<name>, or <kind>/<name> of defaultNamespace
+func parseResource(resource, defaultNamespace string) (kind, namespace, name string, err error) {
+	parts := strings.Split(resource, "/")
+	for _, part := range parts {
+		if part == "" {
+			return "", "", "", fmt.Errorf("--resource must be <kind>/<namespace>/<name> or <kind>/<name>, got %q", resource)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return parts[0], defaultNamespace, parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	default:
+		return "", "", "", fmt.Errorf("--resource must be <kind>/<namespace>/<name> or <kind>/<name>, got %q", resource)
+	}
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTriggerCreatesJob(t *testing.T) {
+	_, clients, err := runPlugin(t, nil,
+		[]runtime.Object{newPluginTestTemplate("my-template", "default")},
+		"trigger", "my-template", "--resource", "Pod/prod/web-1", "--event-type", "delete")
+	if err != nil {
+		t.Fatalf("Failed to trigger template: %v", err)
+	}
+
+	// Like the controller, the job runs in the namespace of the triggering resource
+	jobs, err := clients.KubeClient.BatchV1().Jobs("prod").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Fatalf("Expected one job, got %d", len(jobs.Items))
+	}
+
+	job := jobs.Items[0]
+	if job.Labels[controller.ResourceNameLabel] != "web-1" || job.Labels[controller.EventTypeLabel] != "DELETE" {
+		t.Errorf("Expected the job to be triggered by web-1 with DELETE, got labels %v", job.Labels)
+	}
+	if job.Annotations[controller.ManualTriggerAnnotation] != "true" {
+		t.Errorf("Expected the job to be marked as triggered by hand, got %v", job.Annotations)
+	}
+	if ttl := job.Spec.TTLSecondsAfterFinished; ttl == nil || *ttl != 86400 {
+		t.Errorf("Expected the controller's default ttlSecondsAfterFinished, got %v", ttl)
+	}
+	if backoffLimit := job.Spec.BackoffLimit; backoffLimit == nil || *backoffLimit != 6 {
+		t.Errorf("Expected the controller's default backoffLimit, got %v", backoffLimit)
+	}
+}
+
+func TestTriggerJobDefaults(t *testing.T) {
+	_, clients, err := runPlugin(t, nil,
+		[]runtime.Object{newPluginTestTemplate("my-template", "default")},
+		"trigger", "my-template", "--resource", "Pod/web-1",
+		"--default-job-ttl-seconds", "600", "--default-job-backoff-limit", "-1")
+	if err != nil {
+		t.Fatalf("Failed to trigger template: %v", err)
+	}
+
+	jobs, err := clients.KubeClient.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("Expected one job, got %v, %v", jobs, err)
+	}
+	if ttl := jobs.Items[0].Spec.TTLSecondsAfterFinished; ttl == nil || *ttl != 600 {
+		t.Errorf("Expected ttlSecondsAfterFinished 600, got %v", ttl)
+	}
+	if backoffLimit := jobs.Items[0].Spec.BackoffLimit; backoffLimit != nil {
+		t.Errorf("Expected no backoffLimit, got %d", *backoffLimit)
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	if kind, namespace, name, err := parseResource("Pod/web-1", "default"); err != nil ||
+		kind != "Pod" || namespace != "default" || name != "web-1" {
+		t.Errorf("Expected Pod default/web-1, got %s %s/%s, %v", kind, namespace, name, err)
+	}
+	if kind, namespace, name, err := parseResource("Pod/prod/web-1", "default"); err != nil ||
+		kind != "Pod" || namespace != "prod" || name != "web-1" {
+		t.Errorf("Expected Pod prod/web-1, got %s %s/%s, %v", kind, namespace, name, err)
+	}
+
+	for _, resource := range []string{"", "Pod", "Pod//web-1", "a/b/c/d"} {
+		if _, _, _, err := parseResource(resource, "default"); err == nil {
+			t.Errorf("Expected %q to be rejected", resource)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the controller's spans
+const tracerName = "github.com/roshbhatia/kubanana"
+
+// serviceName identifies the controller in exported spans
+const serviceName = "kubanana-controller"
+
+// traceParentHeader is the W3C trace context header
+const traceParentHeader = "traceparent"
+
+// Config configures the export of spans
+type Config struct {
+	// Endpoint is the host and port of the OTLP/HTTP collector spans are sent to
+	Endpoint string
+
+	// Insecure sends spans over plain HTTP instead of HTTPS
+	Insecure bool
+
+	// SampleRatio is the fraction of triggers that are traced, between 0 and 1
+	SampleRatio float64
+}
+
+// Setup exports the controller's spans to the configured OTLP collector and propagates W3C trace
+// context. The returned function flushes pending spans and stops the export.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("trace sample ratio must be between 0 and 1, got %v", config.SampleRatio)
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the controller. Its spans aren't recorded unless Setup was called.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx, or an empty string if there's none
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get(traceParentHeader)
+}
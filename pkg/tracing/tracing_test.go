@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestCollector starts an OTLP/HTTP collector that sends the names of received spans to the returned channel
+func newTestCollector(t *testing.T) (string, <-chan string) {
+	spans := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read export request: %v", err)
+			return
+		}
+		var request coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &request); err != nil {
+			t.Errorf("Failed to decode export request: %v", err)
+			return
+		}
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					spans <- span.Name
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(nil)
+	}))
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://"), spans
+}
+
+func TestSetupExportsSpans(t *testing.T) {
+	endpoint, spans := newTestCollector(t)
+
+	shutdown, err := Setup(context.Background(), Config{Endpoint: endpoint, Insecure: true, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	ctx, span := Tracer().Start(context.Background(), "ReceiveEvent")
+	traceParent := TraceParent(ctx)
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Failed to flush spans: %v", err)
+	}
+
+	select {
+	case name := <-spans:
+		if name != "ReceiveEvent" {
+			t.Errorf("Expected the ReceiveEvent span, got %q", name)
+		}
+	default:
+		t.Fatalf("Expected the collector to receive the span")
+	}
+
+	traceID := span.SpanContext().TraceID().String()
+	if !strings.HasPrefix(traceParent, "00-"+traceID+"-") || !strings.HasSuffix(traceParent, "-01") {
+		t.Errorf("Expected a sampled traceparent of trace %s, got %q", traceID, traceParent)
+	}
+}
+
+func TestSetupRejectsInvalidSampleRatio(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Endpoint: "localhost:4318", SampleRatio: 2}); err == nil {
+		t.Errorf("Expected an error for a sample ratio above 1")
+	}
+}
+
+func TestTraceParentWithoutSpan(t *testing.T) {
+	if traceParent := TraceParent(context.Background()); traceParent != "" {
+		t.Errorf("Expected no traceparent without a span, got %q", traceParent)
+	}
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// CrossNamespaceLabel grants the EventTriggeredJobs of a namespace labeled with it set to "true"
+// access to resources in other namespaces. Templates of other namespaces only see their own.
+const CrossNamespaceLabel = "kubanana.roshanbhatia.com/cross-namespace"
+
+// CrossNamespaceAllowed checks if the labels of a namespace grant its templates access to other namespaces
+func CrossNamespaceAllowed(namespaceLabels map[string]string) bool {
+	return namespaceLabels[CrossNamespaceLabel] == "true"
+}
+
+// ValidateNamespaceAccess rejects namespace patterns that reach beyond the template's own namespace
+// unless crossNamespaceAllowed. Empty patterns are let through even though they match every namespace;
+// the controllers' canObserve check is what keeps them to the own namespace at runtime.
+func ValidateNamespaceAccess(template *v1alpha1.EventTriggeredJob, crossNamespaceAllowed bool) field.ErrorList {
+	if crossNamespaceAllowed {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if selector := template.Spec.EventSelector; selector != nil {
+		allErrs = append(allErrs, validateOwnNamespace(selector.NamespacePattern, template.Namespace,
+			specPath.Child("eventSelector", "namespacePattern"))...)
+	}
+
+	if selector := template.Spec.StatusSelector; selector != nil {
+		allErrs = append(allErrs, validateOwnNamespace(selector.NamespacePattern, template.Namespace,
+			specPath.Child("statusSelector", "namespacePattern"))...)
+	}
+
+	return allErrs
+}
+
+// validateOwnNamespace checks that pattern is the namespace itself or empty
+func validateOwnNamespace(pattern, namespace string, fldPath *field.Path) field.ErrorList {
+	if pattern == "" || pattern == namespace {
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf(
+		"templates may only watch their own namespace %q unless it is labeled %s=true", namespace, CrossNamespaceLabel))}
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+)
+
+func TestCrossNamespaceAllowed(t *testing.T) {
+	if CrossNamespaceAllowed(nil) {
+		t.Errorf("Expected namespaces without labels not to grant cross-namespace access")
+	}
+	if CrossNamespaceAllowed(map[string]string{CrossNamespaceLabel: "false"}) {
+		t.Errorf("Expected %s=false not to grant cross-namespace access", CrossNamespaceLabel)
+	}
+	if !CrossNamespaceAllowed(map[string]string{CrossNamespaceLabel: "true"}) {
+		t.Errorf("Expected %s=true to grant cross-namespace access", CrossNamespaceLabel)
+	}
+}
+
+func TestValidateNamespaceAccess(t *testing.T) {
+	tests := []struct {
+		name                  string
+		mutate                func(template *v1alpha1.EventTriggeredJob)
+		crossNamespaceAllowed bool
+		fields                []string
+	}{
+		{
+			name:   "no namespace pattern",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {},
+		},
+		{
+			name: "own namespace",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector.NamespacePattern = "team-a"
+			},
+		},
+		{
+			name: "other namespaces",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector.NamespacePattern = "*"
+				template.Spec.StatusSelector = &v1alpha1.StatusSelector{NamespacePattern: "team-*"}
+			},
+			fields: []string{"spec.eventSelector.namespacePattern", "spec.statusSelector.namespacePattern"},
+		},
+		{
+			name: "other namespaces allowed",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector.NamespacePattern = "*"
+			},
+			crossNamespaceAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := newValidTemplate()
+			template.Namespace = "team-a"
+			tt.mutate(template)
+
+			errs := ValidateNamespaceAccess(template, tt.crossNamespaceAllowed)
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs[i].Field != field {
+					t.Errorf("Expected error on %s, got %s", field, errs[i].Field)
+				}
+			}
+		})
+	}
+}
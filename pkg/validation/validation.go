@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidEventTypes are the event types an EventSelector can match
+var ValidEventTypes = []string{"CREATE", "UPDATE", "DELETE"}
+
+// ValidOperators are the operators a StatusCondition can use
+var ValidOperators = []string{"Equal"}
+
+// namePatternRegexp matches name patterns made of object name characters and * wildcards
+var namePatternRegexp = regexp.MustCompile(`^[a-z0-9.*-]+$`)
+
+// ValidateTemplate checks that a template can be used to create jobs. It is used both by the
+// controllers before running a template and by the validating webhook.
+func ValidateTemplate(template *v1alpha1.EventTriggeredJob) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if template.Spec.EventSelector == nil && template.Spec.StatusSelector == nil {
+		allErrs = append(allErrs, field.Required(specPath,
+			"either eventSelector or statusSelector must be specified"))
+	}
+
+	if template.Spec.EventSelector != nil && template.Spec.StatusSelector != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("statusSelector"),
+			"exactly one of eventSelector or statusSelector must be set"))
+	}
+
+	if selector := template.Spec.EventSelector; selector != nil {
+		allErrs = append(allErrs, validateEventSelector(selector, specPath.Child("eventSelector"))...)
+	}
+
+	if selector := template.Spec.StatusSelector; selector != nil {
+		allErrs = append(allErrs, validateStatusSelector(selector, specPath.Child("statusSelector"))...)
+	}
+
+	containersPath := specPath.Child("jobTemplate", "spec", "template", "spec", "containers")
+	if len(template.Spec.JobTemplate.Spec.Template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(containersPath, "at least one container is required"))
+	}
+
+	allErrs = append(allErrs, validateServiceAccount(template, specPath)...)
+
+	return allErrs
+}
+
+// validateEventSelector checks the fields of an EventSelector
+func validateEventSelector(selector *v1alpha1.EventSelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if selector.ResourceKind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceKind"), ""))
+	}
+	allErrs = append(allErrs, ValidateNamePattern(selector.NamePattern, fldPath.Child("namePattern"))...)
+	allErrs = append(allErrs, ValidateNamePattern(selector.NamespacePattern, fldPath.Child("namespacePattern"))...)
+
+	if len(selector.EventTypes) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("eventTypes"), "at least one event type is required"))
+	}
+	for i, eventType := range selector.EventTypes {
+		if !contains(ValidEventTypes, eventType) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("eventTypes").Index(i), eventType, ValidEventTypes))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStatusSelector checks the fields of a StatusSelector
+func validateStatusSelector(selector *v1alpha1.StatusSelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if selector.ResourceKind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceKind"), ""))
+	}
+	allErrs = append(allErrs, ValidateNamePattern(selector.NamePattern, fldPath.Child("namePattern"))...)
+	allErrs = append(allErrs, ValidateNamePattern(selector.NamespacePattern, fldPath.Child("namespacePattern"))...)
+
+	if len(selector.Conditions) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("conditions"), "at least one condition is required"))
+	}
+	for i, condition := range selector.Conditions {
+		conditionPath := fldPath.Child("conditions").Index(i)
+		if condition.Type == "" {
+			allErrs = append(allErrs, field.Required(conditionPath.Child("type"), ""))
+		}
+		if condition.Status == "" {
+			allErrs = append(allErrs, field.Required(conditionPath.Child("status"), ""))
+		}
+		if condition.Operator != "" && !contains(ValidOperators, condition.Operator) {
+			allErrs = append(allErrs, field.NotSupported(conditionPath.Child("operator"), condition.Operator, ValidOperators))
+		}
+	}
+
+	return allErrs
+}
+
+// validateServiceAccount checks the template's service account and that the job's pods don't ask for another one
+func validateServiceAccount(template *v1alpha1.EventTriggeredJob, specPath *field.Path) field.ErrorList {
+	serviceAccount := template.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(serviceAccount) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("serviceAccountName"), serviceAccount, msg))
+	}
+
+	podServiceAccount := template.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName
+	if podServiceAccount != "" && podServiceAccount != serviceAccount {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("jobTemplate", "spec", "template", "spec", "serviceAccountName"), podServiceAccount,
+			"must be empty or match spec.serviceAccountName"))
+	}
+
+	// The service account only exists in the template's namespace, pods elsewhere can't run as it
+	if template.Spec.JobNamespacePolicy == v1alpha1.ResourceJobNamespacePolicy {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("jobNamespacePolicy"),
+			"jobs of templates with a serviceAccountName must run in the template's namespace"))
+	}
+
+	return allErrs
+}
+
+// ValidateNamePattern checks that a name or namespace pattern only uses object name characters
+// and * wildcards. Empty patterns don't filter anything and are valid.
+func ValidateNamePattern(pattern string, fldPath *field.Path) field.ErrorList {
+	if pattern == "" || namePatternRegexp.MatchString(pattern) {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(fldPath, pattern,
+		"must consist of lower case alphanumeric characters, '-', '.' and '*' wildcards")}
+}
+
+// contains checks if values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
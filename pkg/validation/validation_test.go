@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newValidTemplate creates a template that passes validation
+func newValidTemplate() *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			EventSelector: &v1alpha1.EventSelector{
+				ResourceKind: "Pod",
+				EventTypes:   []string{"CREATE"},
+			},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "test", Image: "busybox"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(template *v1alpha1.EventTriggeredJob)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {},
+		},
+		{
+			name: "no selector",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector = nil
+			},
+			fields: []string{"spec"},
+		},
+		{
+			name: "unsupported event type",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector.EventTypes = []string{"CREATE", "RESTART"}
+			},
+			fields: []string{"spec.eventSelector.eventTypes[1]"},
+		},
+		{
+			name: "status selector without conditions",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector = nil
+				template.Spec.StatusSelector = &v1alpha1.StatusSelector{ResourceKind: "Pod"}
+			},
+			fields: []string{"spec.statusSelector.conditions"},
+		},
+		{
+			name: "both selectors",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.StatusSelector = &v1alpha1.StatusSelector{
+					ResourceKind: "Pod",
+					Conditions:   []v1alpha1.StatusCondition{{Type: "Ready", Status: "True"}},
+				}
+			},
+			fields: []string{"spec.statusSelector"},
+		},
+		{
+			name: "bad name pattern",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector.NamePattern = "web-[0-9]"
+				template.Spec.EventSelector.NamespacePattern = "prod-*"
+			},
+			fields: []string{"spec.eventSelector.namePattern"},
+		},
+		{
+			name: "unknown condition operator",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.EventSelector = nil
+				template.Spec.StatusSelector = &v1alpha1.StatusSelector{
+					ResourceKind: "Pod",
+					Conditions: []v1alpha1.StatusCondition{
+						{Type: "Ready", Status: "True", Operator: "Equal"},
+						{Type: "Initialized", Status: "True", Operator: "Like"},
+					},
+				}
+			},
+			fields: []string{"spec.statusSelector.conditions[1].operator"},
+		},
+		{
+			name: "no containers",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.JobTemplate.Spec.Template.Spec.Containers = nil
+			},
+			fields: []string{"spec.jobTemplate.spec.template.spec.containers"},
+		},
+		{
+			name: "service account",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.ServiceAccountName = "deployer"
+				template.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = "deployer"
+			},
+		},
+		{
+			name: "invalid service account",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.ServiceAccountName = "Deployer"
+			},
+			fields: []string{"spec.serviceAccountName"},
+		},
+		{
+			name: "service account with jobs in the resource's namespace",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.ServiceAccountName = "deployer"
+				template.Spec.JobNamespacePolicy = v1alpha1.ResourceJobNamespacePolicy
+			},
+			fields: []string{"spec.jobNamespacePolicy"},
+		},
+		{
+			name: "pods run as another service account",
+			mutate: func(template *v1alpha1.EventTriggeredJob) {
+				template.Spec.ServiceAccountName = "deployer"
+				template.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = "admin"
+			},
+			fields: []string{"spec.jobTemplate.spec.template.spec.serviceAccountName"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := newValidTemplate()
+			tt.mutate(template)
+
+			errs := ValidateTemplate(template)
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs[i].Field != field {
+					t.Errorf("Expected error on %s, got %s", field, errs[i].Field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Keys of the certificate secret, compatible with kubernetes.io/tls secrets
+	caCertKey  = "ca.crt"
+	tlsCertKey = corev1.TLSCertKey
+	tlsKeyKey  = corev1.TLSPrivateKeyKey
+
+	// certValidity is how long generated certificates are valid
+	certValidity = 10 * 365 * 24 * time.Hour
+
+	// certRenewBefore is how long before they expire certificates are replaced on startup
+	certRenewBefore = 90 * 24 * time.Hour
+)
+
+// Certificates holds the serving certificate of the webhook server and the CA that signed it
+type Certificates struct {
+	CACert []byte
+	Cert   []byte
+	Key    []byte
+}
+
+// TLSCertificate returns the serving certificate for a TLS server
+func (c *Certificates) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(c.Cert, c.Key)
+}
+
+// LoadCertificates reads externally managed certificates, e.g. issued by cert-manager, from dir
+func LoadCertificates(dir string) (*Certificates, error) {
+	certs := &Certificates{}
+	for name, data := range map[string]*[]byte{caCertKey: &certs.CACert, tlsCertKey: &certs.Cert, tlsKeyKey: &certs.Key} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			// The CA is only needed to inject it into webhook configurations
+			if name == caCertKey && os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		*data = content
+	}
+
+	return certs, nil
+}
+
+// EnsureCertificates returns the certificates stored in the secret, generating and storing new
+// ones if the secret doesn't exist yet or its certificates are invalid or about to expire. All
+// replicas share the secret so they serve certificates signed by the same CA.
+func EnsureCertificates(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespace, secretName, serviceName string) (*Certificates, error) {
+
+	secrets := kubeClient.CoreV1().Secrets(namespace)
+	dnsName := fmt.Sprintf("%s.%s.svc", serviceName, namespace)
+
+	var certs *Certificates
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := secrets.Get(ctx, secretName, metav1.GetOptions{})
+		found := err == nil
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		if found {
+			if existing := certificatesFromSecret(secret); validCertificates(existing, dnsName, time.Now()) {
+				certs = existing
+				return nil
+			}
+		}
+
+		klog.InfoS("Generating webhook certificates", "dnsName", dnsName)
+		certs, err = generateCertificates(serviceName, namespace, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+				Type:       corev1.SecretTypeTLS,
+				Data:       certificatesData(certs),
+			}
+			_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica was faster, retry to use its certificates
+				return apierrors.NewConflict(corev1.Resource("secrets"), secretName, err)
+			}
+			return err
+		}
+
+		secret.Data = certificatesData(certs)
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure webhook certificates in secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	return certs, nil
+}
+
+// certificatesFromSecret reads certificates stored by EnsureCertificates
+func certificatesFromSecret(secret *corev1.Secret) *Certificates {
+	return &Certificates{
+		CACert: secret.Data[caCertKey],
+		Cert:   secret.Data[tlsCertKey],
+		Key:    secret.Data[tlsKeyKey],
+	}
+}
+
+// certificatesData returns the secret data for certs
+func certificatesData(certs *Certificates) map[string][]byte {
+	return map[string][]byte{
+		caCertKey:  certs.CACert,
+		tlsCertKey: certs.Cert,
+		tlsKeyKey:  certs.Key,
+	}
+}
+
+// validCertificates checks if certs can serve dnsName and are not about to expire
+func validCertificates(certs *Certificates, dnsName string, now time.Time) bool {
+	if len(certs.CACert) == 0 {
+		return false
+	}
+
+	pair, err := tls.X509KeyPair(certs.Cert, certs.Key)
+	if err != nil {
+		return false
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certs.CACert) {
+		return false
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:     dnsName,
+		Roots:       roots,
+		CurrentTime: now.Add(certRenewBefore),
+	})
+	return err == nil
+}
+
+// generateCertificates creates a self-signed CA and a serving certificate for the webhook service
+func generateCertificates(serviceName, namespace string, now time.Time) (*Certificates, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: "kubanana-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serving key: %w", err)
+	}
+
+	dnsNames := []string{
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace),
+	}
+	certTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: dnsNames[2]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, certTemplate, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create serving certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode serving key: %w", err)
+	}
+
+	return &Certificates{
+		CACert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		Cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		Key:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// InjectCABundle sets caBundle on all webhooks of the validating and mutating webhook configurations
+// named configName. A missing configuration is skipped, e.g. when only one kind is installed.
+func InjectCABundle(ctx context.Context, kubeClient kubernetes.Interface, configName string, caBundle []byte) error {
+	admission := kubeClient.AdmissionregistrationV1()
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := admission.ValidatingWebhookConfigurations().Get(ctx, configName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i := range config.Webhooks {
+			if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				config.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		klog.InfoS("Injecting CA bundle", "validatingWebhookConfiguration", configName)
+		_, err = admission.ValidatingWebhookConfigurations().Update(ctx, config, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := admission.MutatingWebhookConfigurations().Get(ctx, configName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i := range config.Webhooks {
+			if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				config.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		klog.InfoS("Injecting CA bundle", "mutatingWebhookConfiguration", configName)
+		_, err = admission.MutatingWebhookConfigurations().Update(ctx, config, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// tlsCertificateForTest generates a serving certificate for tests
+func tlsCertificateForTest(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	certs, err := generateCertificates("kubanana-webhook", "kubanana-system", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to generate certificates: %v", err)
+	}
+	cert, err := certs.TLSCertificate()
+	if err != nil {
+		t.Fatalf("Failed to load certificates: %v", err)
+	}
+
+	return cert
+}
+
+func TestGenerateCertificates(t *testing.T) {
+	now := time.Now()
+	certs, err := generateCertificates("kubanana-webhook", "kubanana-system", now)
+	if err != nil {
+		t.Fatalf("Failed to generate certificates: %v", err)
+	}
+
+	if !validCertificates(certs, "kubanana-webhook.kubanana-system.svc", now) {
+		t.Error("Expected certificates to be valid for the service")
+	}
+	if validCertificates(certs, "other.kubanana-system.svc", now) {
+		t.Error("Expected certificates to be invalid for another service")
+	}
+	if validCertificates(certs, "kubanana-webhook.kubanana-system.svc", now.Add(certValidity)) {
+		t.Error("Expected certificates to need renewal close to expiry")
+	}
+}
+
+func TestEnsureCertificatesSharesSecret(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset()
+
+	first, err := EnsureCertificates(ctx, kubeClient, "kubanana-system", "kubanana-webhook-cert", "kubanana-webhook")
+	if err != nil {
+		t.Fatalf("Failed to ensure certificates: %v", err)
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets("kubanana-system").Get(ctx, "kubanana-webhook-cert", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected certificate secret to be created: %v", err)
+	}
+	if string(secret.Data[tlsCertKey]) != string(first.Cert) {
+		t.Error("Expected secret to hold the generated certificate")
+	}
+
+	// A second replica reuses the stored certificates
+	second, err := EnsureCertificates(ctx, kubeClient, "kubanana-system", "kubanana-webhook-cert", "kubanana-webhook")
+	if err != nil {
+		t.Fatalf("Failed to ensure certificates: %v", err)
+	}
+	if string(second.CACert) != string(first.CACert) {
+		t.Error("Expected the second call to reuse the stored CA")
+	}
+
+	// Certificates for another service are replaced
+	third, err := EnsureCertificates(ctx, kubeClient, "kubanana-system", "kubanana-webhook-cert", "other-webhook")
+	if err != nil {
+		t.Fatalf("Failed to ensure certificates: %v", err)
+	}
+	if string(third.CACert) == string(first.CACert) {
+		t.Error("Expected certificates for another service to be regenerated")
+	}
+}
+
+func TestLoadCertificates(t *testing.T) {
+	certs, err := generateCertificates("kubanana-webhook", "kubanana-system", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to generate certificates: %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{tlsCertKey: certs.Cert, tlsKeyKey: certs.Key} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	loaded, err := LoadCertificates(dir)
+	if err != nil {
+		t.Fatalf("Failed to load certificates: %v", err)
+	}
+	if len(loaded.CACert) != 0 {
+		t.Error("Expected no CA without ca.crt")
+	}
+	if _, err := loaded.TLSCertificate(); err != nil {
+		t.Errorf("Expected loaded certificates to be usable: %v", err)
+	}
+}
+
+func TestInjectCABundle(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset(&admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubanana"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{Name: "validate.kubanana.roshanbhatia.com"},
+		},
+	}, &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubanana"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: "default.kubanana.roshanbhatia.com"},
+		},
+	})
+
+	if err := InjectCABundle(ctx, kubeClient, "kubanana", []byte("ca")); err != nil {
+		t.Fatalf("Failed to inject CA bundle: %v", err)
+	}
+
+	config, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, "kubanana", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get webhook configuration: %v", err)
+	}
+	if string(config.Webhooks[0].ClientConfig.CABundle) != "ca" {
+		t.Errorf("Expected CA bundle to be injected, got %q", config.Webhooks[0].ClientConfig.CABundle)
+	}
+
+	mutating, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, "kubanana", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get mutating webhook configuration: %v", err)
+	}
+	if string(mutating.Webhooks[0].ClientConfig.CABundle) != "ca" {
+		t.Errorf("Expected CA bundle to be injected, got %q", mutating.Webhooks[0].ClientConfig.CABundle)
+	}
+
+	// Missing configurations are skipped
+	if err := InjectCABundle(ctx, fake.NewSimpleClientset(), "kubanana", []byte("ca")); err != nil {
+		t.Errorf("Expected missing configurations to be skipped, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// ConvertPath is the path of the EventTriggeredJob conversion webhook
+const ConvertPath = "/convert"
+
+// CRDName is the name of the EventTriggeredJob CustomResourceDefinition
+const CRDName = "eventtriggeredjobs.kubanana.roshanbhatia.com"
+
+// crdResource is the resource of CustomResourceDefinitions, which are updated through the dynamic client
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// conversionReview is the part of an apiextensions.k8s.io/v1 ConversionReview the webhook uses
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+// conversionRequest asks to convert objects to the desired API version
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+// conversionResponse returns the converted objects in the order they were requested
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// conversionHandler decodes ConversionReviews and converts their objects
+func conversionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := &conversionReview{}
+		if err := json.Unmarshal(body, review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode conversion review: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "conversion review has no request", http.StatusBadRequest)
+			return
+		}
+
+		response := convertObjects(review.Request)
+		response.UID = review.Request.UID
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&conversionReview{
+			TypeMeta: review.TypeMeta,
+			Response: response,
+		}); err != nil {
+			klog.ErrorS(err, "Error encoding conversion review")
+		}
+	})
+}
+
+// convertObjects converts every object of the request, failing the whole request on the first error
+func convertObjects(request *conversionRequest) *conversionResponse {
+	response := &conversionResponse{}
+	for _, object := range request.Objects {
+		converted, err := convertTemplate(object.Raw, request.DesiredAPIVersion)
+		if err != nil {
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convertTemplate converts an encoded EventTriggeredJob to the desired API version through v1alpha1
+func convertTemplate(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	hub := &v1alpha1.EventTriggeredJob{}
+	switch typeMeta.APIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		if err := json.Unmarshal(raw, hub); err != nil {
+			return nil, fmt.Errorf("failed to decode EventTriggeredJob: %w", err)
+		}
+	case v1beta1.SchemeGroupVersion.String():
+		template := &v1beta1.EventTriggeredJob{}
+		if err := json.Unmarshal(raw, template); err != nil {
+			return nil, fmt.Errorf("failed to decode EventTriggeredJob: %w", err)
+		}
+		if err := template.ConvertTo(hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported API version %q", typeMeta.APIVersion)
+	}
+
+	switch desiredAPIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		return json.Marshal(hub)
+	case v1beta1.SchemeGroupVersion.String():
+		template := &v1beta1.EventTriggeredJob{}
+		if err := template.ConvertFrom(hub); err != nil {
+			return nil, err
+		}
+		return json.Marshal(template)
+	default:
+		return nil, fmt.Errorf("unsupported API version %q", desiredAPIVersion)
+	}
+}
+
+// ConfigureConversion points the conversion of the CRD crdName at the webhook service and serves all
+// of its versions. An empty caBundle keeps the CRD's current one, e.g. injected by a certificate issuer.
+func ConfigureConversion(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	crdName, serviceNamespace, serviceName string,
+	caBundle []byte) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := dynamicClient.Resource(crdResource).Get(ctx, crdName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		clientConfig := map[string]interface{}{
+			"service": map[string]interface{}{
+				"namespace": serviceNamespace,
+				"name":      serviceName,
+				"path":      ConvertPath,
+			},
+		}
+		if len(caBundle) > 0 {
+			clientConfig["caBundle"] = base64.StdEncoding.EncodeToString(caBundle)
+		} else if current, found, _ := unstructured.NestedString(crd.Object,
+			"spec", "conversion", "webhook", "clientConfig", "caBundle"); found {
+			clientConfig["caBundle"] = current
+		}
+
+		conversion := map[string]interface{}{
+			"strategy": "Webhook",
+			"webhook": map[string]interface{}{
+				"clientConfig":             clientConfig,
+				"conversionReviewVersions": []interface{}{"v1"},
+			},
+		}
+		if err := unstructured.SetNestedMap(crd.Object, conversion, "spec", "conversion"); err != nil {
+			return err
+		}
+
+		// Versions other than the storage version are only served once they can be converted
+		versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			if version, ok := version.(map[string]interface{}); ok {
+				version["served"] = true
+			}
+		}
+		if err := unstructured.SetNestedSlice(crd.Object, versions, "spec", "versions"); err != nil {
+			return err
+		}
+
+		_, err = dynamicClient.Resource(crdResource).Update(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
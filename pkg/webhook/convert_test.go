@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// convert posts a ConversionReview for objects to the conversion webhook and returns the response
+func convert(t *testing.T, desiredAPIVersion string, objects ...interface{}) *conversionResponse {
+	t.Helper()
+
+	request := &conversionRequest{UID: "test-uid", DesiredAPIVersion: desiredAPIVersion}
+	for _, object := range objects {
+		raw, err := json.Marshal(object)
+		if err != nil {
+			t.Fatalf("Failed to encode object: %v", err)
+		}
+		request.Objects = append(request.Objects, runtime.RawExtension{Raw: raw})
+	}
+
+	body, err := json.Marshal(&conversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request:  request,
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode conversion review: %v", err)
+	}
+
+	server := NewServer(":0", tlsCertificateForTest(t), Defaults{}, nil)
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	review := &conversionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), review); err != nil {
+		t.Fatalf("Failed to decode conversion review: %v", err)
+	}
+	if review.Response == nil || review.Response.UID != "test-uid" {
+		t.Fatalf("Expected a response for request test-uid, got %+v", review.Response)
+	}
+
+	return review.Response
+}
+
+func TestConvertToV1beta1(t *testing.T) {
+	template := newWebhookTestTemplate()
+	template.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	template.Kind = "EventTriggeredJob"
+
+	response := convert(t, v1beta1.SchemeGroupVersion.String(), template)
+	if response.Result.Status != metav1.StatusSuccess || len(response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected one converted object, got %+v", response)
+	}
+
+	converted := &v1beta1.EventTriggeredJob{}
+	if err := json.Unmarshal(response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("Failed to decode converted object: %v", err)
+	}
+	if converted.APIVersion != "kubanana.roshanbhatia.com/v1beta1" || converted.Kind != "EventTriggeredJob" {
+		t.Errorf("Expected a v1beta1 EventTriggeredJob, got %s %s", converted.APIVersion, converted.Kind)
+	}
+	if converted.Spec.Target.Kind != "Pod" || len(converted.Spec.Triggers) != 1 || converted.Spec.Triggers[0].Event == nil {
+		t.Errorf("Expected an event trigger on pods, got %+v", converted.Spec)
+	}
+}
+
+func TestConvertToV1alpha1(t *testing.T) {
+	template := &v1beta1.EventTriggeredJob{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "EventTriggeredJob"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec: v1beta1.EventTriggeredJobSpec{
+			Target: v1beta1.ResourceSelector{Kind: "Deployment"},
+			Triggers: []v1beta1.Trigger{
+				{Status: &v1beta1.StatusTrigger{Conditions: []v1beta1.StatusCondition{{Type: "Available", Status: "True"}}}},
+			},
+		},
+	}
+
+	response := convert(t, v1alpha1.SchemeGroupVersion.String(), template)
+	if response.Result.Status != metav1.StatusSuccess || len(response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected one converted object, got %+v", response)
+	}
+
+	converted := &v1alpha1.EventTriggeredJob{}
+	if err := json.Unmarshal(response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("Failed to decode converted object: %v", err)
+	}
+	if converted.APIVersion != "kubanana.roshanbhatia.com/v1alpha1" {
+		t.Errorf("Expected a v1alpha1 EventTriggeredJob, got %s", converted.APIVersion)
+	}
+	if converted.Spec.EventSelector != nil {
+		t.Errorf("Expected no event selector, got %+v", converted.Spec.EventSelector)
+	}
+	if converted.Spec.StatusSelector == nil || converted.Spec.StatusSelector.ResourceKind != "Deployment" {
+		t.Errorf("Expected a status selector on deployments, got %+v", converted.Spec.StatusSelector)
+	}
+}
+
+func TestConvertFailsUnsupportedVersion(t *testing.T) {
+	template := newWebhookTestTemplate()
+	template.APIVersion = v1alpha1.SchemeGroupVersion.String()
+
+	response := convert(t, "kubanana.roshanbhatia.com/v2", template)
+	if response.Result.Status != metav1.StatusFailure || len(response.ConvertedObjects) != 0 {
+		t.Errorf("Expected the conversion to fail, got %+v", response)
+	}
+}
+
+func TestConversionHandlerRejectsBadRequests(t *testing.T) {
+	handler := conversionHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ConvertPath, nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for GET, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader([]byte("{}"))))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a request, got %d", recorder.Code)
+	}
+}
+
+func TestConfigureConversion(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": CRDName},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1alpha1", "served": true, "storage": true},
+				map[string]interface{}{"name": "v1beta1", "served": false, "storage": false},
+			},
+		},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"}, crd)
+
+	if err := ConfigureConversion(context.Background(), dynamicClient, CRDName, "kubanana-system", "kubanana-webhook", []byte("ca")); err != nil {
+		t.Fatalf("ConfigureConversion failed: %v", err)
+	}
+
+	updated, err := dynamicClient.Resource(crdResource).Get(context.Background(), CRDName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get CRD: %v", err)
+	}
+
+	strategy, _, _ := unstructured.NestedString(updated.Object, "spec", "conversion", "strategy")
+	if strategy != "Webhook" {
+		t.Errorf("Expected the Webhook conversion strategy, got %q", strategy)
+	}
+	path, _, _ := unstructured.NestedString(updated.Object, "spec", "conversion", "webhook", "clientConfig", "service", "path")
+	if path != ConvertPath {
+		t.Errorf("Expected conversion path %s, got %q", ConvertPath, path)
+	}
+	caBundle, _, _ := unstructured.NestedString(updated.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	if caBundle != "Y2E=" {
+		t.Errorf("Expected the encoded CA bundle, got %q", caBundle)
+	}
+
+	versions, _, _ := unstructured.NestedSlice(updated.Object, "spec", "versions")
+	for _, version := range versions {
+		version := version.(map[string]interface{})
+		if version["served"] != true {
+			t.Errorf("Expected version %s to be served", version["name"])
+		}
+	}
+
+	// Externally managed certificates keep the injected CA bundle
+	if err := ConfigureConversion(context.Background(), dynamicClient, CRDName, "kubanana-system", "kubanana-webhook", nil); err != nil {
+		t.Fatalf("ConfigureConversion failed: %v", err)
+	}
+	updated, _ = dynamicClient.Resource(crdResource).Get(context.Background(), CRDName, metav1.GetOptions{})
+	caBundle, _, _ = unstructured.NestedString(updated.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	if caBundle != "Y2E=" {
+		t.Errorf("Expected the CA bundle to be kept, got %q", caBundle)
+	}
+}
+
+func TestConfigureConversionMissingCRD(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"})
+
+	if err := ConfigureConversion(context.Background(), dynamicClient, CRDName, "kubanana-system", "kubanana-webhook", nil); err == nil {
+		t.Error("Expected an error for a missing CRD")
+	}
+}
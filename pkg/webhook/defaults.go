@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Defaults are the configurable defaults applied to EventTriggeredJobs on admission
+type Defaults struct {
+	// BackoffLimit is set on job templates without a backoffLimit. Nil leaves it to the Job API.
+	BackoffLimit *int32
+
+	// TTLSecondsAfterFinished is set on job templates without a ttlSecondsAfterFinished. Nil disables it.
+	TTLSecondsAfterFinished *int32
+}
+
+// SetTemplateDefaults fills in the fields the template left unset, so the effective spec is stored
+func SetTemplateDefaults(template *v1alpha1.EventTriggeredJob, defaults Defaults) {
+	spec := &template.Spec
+
+	if selector := spec.EventSelector; selector != nil {
+		for i, eventType := range selector.EventTypes {
+			selector.EventTypes[i] = strings.ToUpper(eventType)
+		}
+	}
+
+	if selector := spec.StatusSelector; selector != nil {
+		for i := range selector.Conditions {
+			if selector.Conditions[i].Operator == "" {
+				selector.Conditions[i].Operator = "Equal"
+			}
+		}
+	}
+
+	if spec.ConcurrencyPolicy == "" {
+		spec.ConcurrencyPolicy = v1alpha1.AllowConcurrent
+	}
+	if spec.ConcurrencyScope == "" {
+		spec.ConcurrencyScope = v1alpha1.TemplateConcurrencyScope
+	}
+	if spec.SuspendPolicy == "" {
+		spec.SuspendPolicy = v1alpha1.DropSuspendPolicy
+	}
+	if spec.Debounce != nil && spec.Debounce.Key == "" {
+		spec.Debounce.Key = v1alpha1.TemplateDebounceKey
+	}
+
+	jobSpec := &spec.JobTemplate.Spec
+	if jobSpec.Template.Spec.RestartPolicy == "" {
+		jobSpec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	if jobSpec.BackoffLimit == nil && defaults.BackoffLimit != nil {
+		backoffLimit := *defaults.BackoffLimit
+		jobSpec.BackoffLimit = &backoffLimit
+	}
+	if jobSpec.TTLSecondsAfterFinished == nil && defaults.TTLSecondsAfterFinished != nil {
+		ttl := *defaults.TTLSecondsAfterFinished
+		jobSpec.TTLSecondsAfterFinished = &ttl
+	}
+}
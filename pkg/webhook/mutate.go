@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MutatePath is the path of the EventTriggeredJob defaulting webhook
+const MutatePath = "/mutate-eventtriggeredjob"
+
+// patchOperation is a single JSON patch (RFC 6902) operation
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// defaultTemplate returns an admitFunc patching the defaults into EventTriggeredJobs
+func defaultTemplate(defaults Defaults) admitFunc {
+	return func(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+		if request.Operation == admissionv1.Delete {
+			return allowed()
+		}
+
+		template := &v1alpha1.EventTriggeredJob{}
+		if err := json.Unmarshal(request.Object.Raw, template); err != nil {
+			return denied(metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusBadRequest,
+				Reason:  metav1.StatusReasonBadRequest,
+				Message: "failed to decode EventTriggeredJob: " + err.Error(),
+			})
+		}
+
+		defaulted := template.DeepCopy()
+		SetTemplateDefaults(defaulted, defaults)
+
+		patch, err := createPatch(request.Object.Raw, template, defaulted)
+		if err != nil {
+			return denied(metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusInternalServerError,
+				Reason:  metav1.StatusReasonInternalError,
+				Message: "failed to create patch: " + err.Error(),
+			})
+		}
+
+		response := allowed()
+		if len(patch) > 0 {
+			patchType := admissionv1.PatchTypeJSONPatch
+			response.Patch = patch
+			response.PatchType = &patchType
+		}
+
+		return response
+	}
+}
+
+// createPatch returns a JSON patch turning original into defaulted. Only values that differ
+// between the two typed objects are patched, so fields unknown to the types are left alone.
+func createPatch(raw []byte, original, defaulted interface{}) ([]byte, error) {
+	var rawObj, originalObj, defaultedObj interface{}
+	if err := json.Unmarshal(raw, &rawObj); err != nil {
+		return nil, err
+	}
+	if err := roundTrip(original, &originalObj); err != nil {
+		return nil, err
+	}
+	if err := roundTrip(defaulted, &defaultedObj); err != nil {
+		return nil, err
+	}
+
+	var operations []patchOperation
+	diff(rawObj, originalObj, defaultedObj, "", &operations)
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(operations)
+}
+
+// roundTrip converts obj into its generic JSON representation
+func roundTrip(obj interface{}, into *interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
+
+// diff appends the operations setting the values changed between original and defaulted. Objects
+// are compared key by key against what the request actually contained, lists are replaced as a whole.
+func diff(raw, original, defaulted interface{}, path string, operations *[]patchOperation) {
+	rawMap, rawIsMap := raw.(map[string]interface{})
+	originalMap, originalIsMap := original.(map[string]interface{})
+	defaultedMap, defaultedIsMap := defaulted.(map[string]interface{})
+
+	if !rawIsMap || !originalIsMap || !defaultedIsMap {
+		*operations = append(*operations, patchOperation{Op: "replace", Path: path, Value: defaulted})
+		return
+	}
+
+	keys := make([]string, 0, len(defaultedMap))
+	for key := range defaultedMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := defaultedMap[key]
+		if reflect.DeepEqual(originalMap[key], value) {
+			continue
+		}
+
+		childPath := path + "/" + escapePointer(key)
+		rawValue, exists := rawMap[key]
+		if !exists {
+			*operations = append(*operations, patchOperation{Op: "add", Path: childPath, Value: value})
+			continue
+		}
+
+		diff(rawValue, originalMap[key], value, childPath, operations)
+	}
+}
+
+// escapePointer escapes a key for use in a JSON pointer (RFC 6901)
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// rawTemplate is an EventTriggeredJob as a user would apply it, including a field the types don't know
+const rawTemplate = `{
+  "apiVersion": "kubanana.roshanbhatia.com/v1alpha1",
+  "kind": "EventTriggeredJob",
+  "metadata": {"name": "test-template", "namespace": "default"},
+  "spec": {
+    "eventSelector": {"resourceKind": "Pod", "eventTypes": ["create", "Delete"]},
+    "statusSelector": {"resourceKind": "Pod", "conditions": [{"type": "Ready", "status": "True"}]},
+    "jobTemplate": {
+      "spec": {
+        "template": {
+          "spec": {"containers": [{"name": "test", "image": "busybox"}]},
+          "unknownField": "kept"
+        }
+      }
+    }
+  }
+}`
+
+// mutate posts an AdmissionReview for raw to the defaulting webhook and returns the patched object
+func mutate(t *testing.T, raw string, defaults Defaults) map[string]interface{} {
+	t.Helper()
+
+	body, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "test-uid",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(raw)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode admission review: %v", err)
+	}
+
+	server := NewServer(":0", tlsCertificateForTest(t), defaults, nil)
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, MutatePath, bytes.NewReader(body)))
+
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), review); err != nil {
+		t.Fatalf("Failed to decode admission review: %v", err)
+	}
+	if !review.Response.Allowed {
+		t.Fatalf("Expected template to be allowed, got %+v", review.Response.Result)
+	}
+
+	patched := []byte(raw)
+	if review.Response.Patch != nil {
+		patch, err := jsonpatch.DecodePatch(review.Response.Patch)
+		if err != nil {
+			t.Fatalf("Failed to decode patch: %v", err)
+		}
+		if patched, err = patch.Apply(patched); err != nil {
+			t.Fatalf("Failed to apply patch %s: %v", review.Response.Patch, err)
+		}
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(patched, &obj); err != nil {
+		t.Fatalf("Failed to decode patched object: %v", err)
+	}
+	return obj
+}
+
+// lookup returns the value at path in obj
+func lookup(obj interface{}, path ...interface{}) interface{} {
+	for _, key := range path {
+		switch k := key.(type) {
+		case string:
+			obj = obj.(map[string]interface{})[k]
+		case int:
+			obj = obj.([]interface{})[k]
+		}
+	}
+	return obj
+}
+
+func TestDefaultTemplate(t *testing.T) {
+	backoffLimit := int32(2)
+	ttl := int32(3600)
+	obj := mutate(t, rawTemplate, Defaults{BackoffLimit: &backoffLimit, TTLSecondsAfterFinished: &ttl})
+
+	tests := []struct {
+		path     []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{"spec", "eventSelector", "eventTypes", 0}, "CREATE"},
+		{[]interface{}{"spec", "eventSelector", "eventTypes", 1}, "DELETE"},
+		{[]interface{}{"spec", "statusSelector", "conditions", 0, "operator"}, "Equal"},
+		{[]interface{}{"spec", "concurrencyPolicy"}, "Allow"},
+		{[]interface{}{"spec", "concurrencyScope"}, "Template"},
+		{[]interface{}{"spec", "suspendPolicy"}, "Drop"},
+		{[]interface{}{"spec", "jobNamespacePolicy"}, nil},
+		{[]interface{}{"spec", "jobTemplate", "spec", "template", "spec", "restartPolicy"}, "Never"},
+		{[]interface{}{"spec", "jobTemplate", "spec", "backoffLimit"}, float64(2)},
+		{[]interface{}{"spec", "jobTemplate", "spec", "ttlSecondsAfterFinished"}, float64(3600)},
+		{[]interface{}{"spec", "jobTemplate", "spec", "template", "unknownField"}, "kept"},
+	}
+
+	for _, tt := range tests {
+		if value := lookup(obj, tt.path...); value != tt.expected {
+			t.Errorf("Expected %v at %v, got %v", tt.expected, tt.path, value)
+		}
+	}
+
+	if _, ok := lookup(obj, "spec").(map[string]interface{})["debounce"]; ok {
+		t.Error("Expected no debounce to be added")
+	}
+}
+
+func TestDefaultTemplateKeepsExplicitValues(t *testing.T) {
+	backoffLimit := int32(2)
+	obj := mutate(t, `{
+  "spec": {
+    "eventSelector": {"resourceKind": "Pod", "eventTypes": ["UPDATE"]},
+    "concurrencyPolicy": "Forbid",
+    "jobNamespacePolicy": "Resource",
+    "debounce": {"window": "10s"},
+    "jobTemplate": {
+      "spec": {
+        "backoffLimit": 0,
+        "template": {"spec": {"restartPolicy": "OnFailure", "containers": [{"name": "test", "image": "busybox"}]}}
+      }
+    }
+  }
+}`, Defaults{BackoffLimit: &backoffLimit})
+
+	tests := []struct {
+		path     []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{"spec", "eventSelector", "eventTypes", 0}, "UPDATE"},
+		{[]interface{}{"spec", "concurrencyPolicy"}, "Forbid"},
+		{[]interface{}{"spec", "jobNamespacePolicy"}, "Resource"},
+		{[]interface{}{"spec", "debounce", "key"}, "Template"},
+		{[]interface{}{"spec", "debounce", "window"}, "10s"},
+		{[]interface{}{"spec", "jobTemplate", "spec", "backoffLimit"}, float64(0)},
+		{[]interface{}{"spec", "jobTemplate", "spec", "template", "spec", "restartPolicy"}, "OnFailure"},
+	}
+
+	for _, tt := range tests {
+		if value := lookup(obj, tt.path...); value != tt.expected {
+			t.Errorf("Expected %v at %v, got %v", tt.expected, tt.path, value)
+		}
+	}
+
+	if _, ok := lookup(obj, "spec", "jobTemplate", "spec").(map[string]interface{})["ttlSecondsAfterFinished"]; ok {
+		t.Error("Expected no TTL without a default")
+	}
+}
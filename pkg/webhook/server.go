@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// maxRequestBytes limits the size of admission reviews, objects are capped at ~1.5MB by etcd anyway
+const maxRequestBytes = 3 * 1024 * 1024
+
+// admitFunc reviews a single admission request
+type admitFunc func(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+
+// Server serves Kubanana's admission and conversion webhooks over TLS
+type Server struct {
+	addr string
+	cert tls.Certificate
+	mux  *http.ServeMux
+}
+
+// NewServer creates a webhook server listening on addr with the serving certificate cert. EventTriggeredJobs
+// are defaulted with defaults before they are validated, namespaceLabels grants them access to other namespaces.
+func NewServer(addr string, cert tls.Certificate, defaults Defaults, namespaceLabels NamespaceLabels) *Server {
+	s := &Server{
+		addr: addr,
+		cert: cert,
+		mux:  http.NewServeMux(),
+	}
+
+	s.mux.Handle(MutatePath, admissionHandler(defaultTemplate(defaults)))
+	s.mux.Handle(ValidatePath, admissionHandler(validateTemplate(namespaceLabels)))
+	s.mux.Handle(ConvertPath, conversionHandler())
+
+	return s
+}
+
+// Run serves the webhooks until stopCh is closed
+func (s *Server) Run(stopCh <-chan struct{}) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{s.cert},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			klog.ErrorS(err, "Error shutting down webhook server")
+		}
+	}()
+
+	klog.InfoS("Serving webhooks", "address", s.addr)
+	if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// admissionHandler decodes AdmissionReviews, passes their request to admit and encodes its response
+func admissionHandler(admit admitFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := &admissionv1.AdmissionReview{}
+		if err := json.Unmarshal(body, review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "admission review has no request", http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&admissionv1.AdmissionReview{
+			TypeMeta: review.TypeMeta,
+			Response: response,
+		}); err != nil {
+			klog.ErrorS(err, "Error encoding admission review")
+		}
+	})
+}
+
+// allowed returns a response admitting the request
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// denied returns a response rejecting the request with status
+func denied(status metav1.Status) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: false, Result: &status}
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// ValidatePath is the path of the EventTriggeredJob validating webhook
+const ValidatePath = "/validate-eventtriggeredjob"
+
+// templateGroupKind is reported in the field errors of rejected templates
+var templateGroupKind = v1alpha1.SchemeGroupVersion.WithKind("EventTriggeredJob").GroupKind()
+
+// NamespaceLabels looks up the labels of a namespace
+type NamespaceLabels func(ctx context.Context, name string) (map[string]string, error)
+
+// NamespaceLabelsFromClient looks up namespace labels with kubeClient
+func NamespaceLabelsFromClient(kubeClient kubernetes.Interface) NamespaceLabels {
+	return func(ctx context.Context, name string) (map[string]string, error) {
+		namespace, err := kubeClient.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return namespace.Labels, nil
+	}
+}
+
+// validateTemplate rejects EventTriggeredJobs the controllers would refuse to run, including templates
+// watching other namespaces without the grant of namespaceLabels
+func validateTemplate(namespaceLabels NamespaceLabels) admitFunc {
+	return func(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+		if request.Operation == admissionv1.Delete {
+			return allowed()
+		}
+
+		template := &v1alpha1.EventTriggeredJob{}
+		if err := json.Unmarshal(request.Object.Raw, template); err != nil {
+			return denied(metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusBadRequest,
+				Reason:  metav1.StatusReasonBadRequest,
+				Message: "failed to decode EventTriggeredJob: " + err.Error(),
+			})
+		}
+		if template.Namespace == "" {
+			template.Namespace = request.Namespace
+		}
+
+		errs := validation.ValidateTemplate(template)
+		errs = append(errs, validation.ValidateNamespaceAccess(template,
+			crossNamespaceAllowed(namespaceLabels, template.Namespace))...)
+		if len(errs) > 0 {
+			return denied(apierrors.NewInvalid(templateGroupKind, template.Name, errs).ErrStatus)
+		}
+
+		return allowed()
+	}
+}
+
+// crossNamespaceAllowed checks if namespace grants its templates access to other namespaces. Namespaces
+// whose labels can't be looked up don't.
+func crossNamespaceAllowed(namespaceLabels NamespaceLabels, namespace string) bool {
+	if namespaceLabels == nil {
+		return false
+	}
+
+	labels, err := namespaceLabels(context.Background(), namespace)
+	if err != nil {
+		klog.ErrorS(err, "Failed to look up namespace labels", "namespace", namespace)
+		return false
+	}
+
+	return validation.CrossNamespaceAllowed(labels)
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/roshbhatia/kubanana/pkg/apis/kubanana/v1alpha1"
+	"github.com/roshbhatia/kubanana/pkg/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newWebhookTestTemplate creates a template that passes validation
+func newWebhookTestTemplate() *v1alpha1.EventTriggeredJob {
+	return &v1alpha1.EventTriggeredJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+		Spec: v1alpha1.EventTriggeredJobSpec{
+			EventSelector: &v1alpha1.EventSelector{
+				ResourceKind: "Pod",
+				EventTypes:   []string{"CREATE"},
+			},
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "test", Image: "busybox"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// review posts an AdmissionReview for template to path and returns the response
+func review(t *testing.T, path string, template *v1alpha1.EventTriggeredJob) *admissionv1.AdmissionResponse {
+	t.Helper()
+
+	raw, err := json.Marshal(template)
+	if err != nil {
+		t.Fatalf("Failed to encode template: %v", err)
+	}
+
+	body, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("test-uid"),
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode admission review: %v", err)
+	}
+
+	server := NewServer(":0", tlsCertificateForTest(t), Defaults{}, nil)
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	response := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatalf("Failed to decode admission review: %v", err)
+	}
+	if response.Response == nil || response.Response.UID != "test-uid" {
+		t.Fatalf("Expected a response for request test-uid, got %+v", response.Response)
+	}
+
+	return response.Response
+}
+
+func TestValidateAllowsValidTemplate(t *testing.T) {
+	response := review(t, ValidatePath, newWebhookTestTemplate())
+	if !response.Allowed {
+		t.Errorf("Expected valid template to be allowed, got %+v", response.Result)
+	}
+}
+
+func TestValidateRejectsInvalidTemplate(t *testing.T) {
+	template := newWebhookTestTemplate()
+	template.Spec.EventSelector.EventTypes = []string{"RESTART"}
+	template.Spec.EventSelector.NamePattern = "web_[0-9]"
+	template.Spec.JobTemplate.Spec.Template.Spec.Containers = nil
+
+	response := review(t, ValidatePath, template)
+	if response.Allowed {
+		t.Fatal("Expected invalid template to be rejected")
+	}
+	if response.Result.Reason != metav1.StatusReasonInvalid {
+		t.Errorf("Expected reason Invalid, got %s", response.Result.Reason)
+	}
+
+	fields := map[string]bool{}
+	for _, cause := range response.Result.Details.Causes {
+		fields[cause.Field] = true
+	}
+	for _, field := range []string{
+		"spec.eventSelector.eventTypes[0]",
+		"spec.eventSelector.namePattern",
+		"spec.jobTemplate.spec.template.spec.containers",
+	} {
+		if !fields[field] {
+			t.Errorf("Expected a cause for field %s, got %v", field, response.Result.Details.Causes)
+		}
+	}
+}
+
+func TestValidateRestrictsNamespacePatterns(t *testing.T) {
+	template := newWebhookTestTemplate()
+	template.Namespace = ""
+	template.Spec.EventSelector.NamespacePattern = "prod-*"
+	raw, err := json.Marshal(template)
+	if err != nil {
+		t.Fatalf("Failed to encode template: %v", err)
+	}
+	request := &admissionv1.AdmissionRequest{
+		Namespace: "default",
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+
+	kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	response := validateTemplate(NamespaceLabelsFromClient(kubeClient))(request)
+	if response.Allowed {
+		t.Fatal("Expected a template watching other namespaces to be rejected")
+	}
+	if causes := response.Result.Details.Causes; len(causes) != 1 || causes[0].Field != "spec.eventSelector.namespacePattern" {
+		t.Errorf("Expected a cause for field spec.eventSelector.namespacePattern, got %v", causes)
+	}
+
+	kubeClient = fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "default",
+		Labels: map[string]string{validation.CrossNamespaceLabel: "true"},
+	}})
+	if response := validateTemplate(NamespaceLabelsFromClient(kubeClient))(request); !response.Allowed {
+		t.Errorf("Expected a template of a granted namespace to be allowed, got %+v", response.Result)
+	}
+}
+
+func TestAdmissionHandlerRejectsBadRequests(t *testing.T) {
+	handler := admissionHandler(validateTemplate(nil))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ValidatePath, nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for GET, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader([]byte("{}"))))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a request, got %d", recorder.Code)
+	}
+}